@@ -1,13 +1,12 @@
 package cmd
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/matheusmacan/configparser/config"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 var filePath string
@@ -16,20 +15,8 @@ var parseCmd = &cobra.Command{
 	Use:   "parse",
 	Short: "Faz o parse de um arquivo de configuração YAML ou JSON",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
-		}
-
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg)
-		} else {
-			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
-			os.Exit(1)
-		}
+		cfg := loadConfig(filePath)
+		fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg)
 	},
 }
 
@@ -37,33 +24,30 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Imprimi somente os servidores",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
-		}
-
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg.Servers)
-		} else {
-			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
-			os.Exit(1)
-		}
+		cfg := loadConfig(filePath)
+		fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg.Servers)
 	},
 }
 
-func validateConfig(cfg config.Config) {
-	for i, server := range cfg.Servers {
-		if server.Name == "" || server.Host == "" || server.Port == 0 {
-			fmt.Printf("Servidor #%d com campos obrigatórios ausentes\n", i)
+// exitInvalidConfig é o código de saída usado quando a validação encontra erros,
+// permitindo que pipelines diferenciem configuração inválida de falhas de leitura.
+const exitInvalidConfig = 2
+
+// loadConfig carrega o arquivo com config.Load, encerrando o processo em caso de erro.
+func loadConfig(path string) config.Config {
+	cfg, err := config.Load(path)
+	if err != nil {
+		var errs config.ValidationErrors
+		if errors.As(err, &errs) {
+			fmt.Fprintln(os.Stderr, errs.Error())
+			fmt.Fprintln(os.Stderr, "Configuração inválida.")
+			os.Exit(exitInvalidConfig)
 		}
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+		os.Exit(1)
 	}
-	db := cfg.Database
-	if db.Host == "" || db.Port == 0 || db.User == "" {
-		fmt.Println("Configuração do banco de dados com campos obrigatórios ausentes")
-	}
+	return *cfg
 }
 
 func init() {
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+
+	parser "configparser-exerc02/config"
+)
+
+// Defaults são valores aplicados aos campos não informados de cada entrada.
+type Defaults struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+}
+
+// As opções são as do Load do configparser (exerc02).
+type LoadOption = parser.LoadOption
+
+var (
+	WithStrict          = parser.WithStrict
+	WithValidation      = parser.WithValidation
+	WithoutEnv          = parser.WithoutEnv
+	WithAllowMissingEnv = parser.WithAllowMissingEnv
+)
+
+// WithLookupEnv troca a origem das variáveis de ambiente (útil em testes).
+func WithLookupEnv(lookup func(string) (string, bool)) LoadOption {
+	return parser.WithEnv(parser.Interpolator{LookupEnv: lookup, ReadFile: os.ReadFile})
+}
+
+// WithDefaults aplica valores padrão aos campos vazios após o parse.
+func WithDefaults(d Defaults) LoadOption {
+	return parser.WithEntryDefaults(
+		parser.EntryDefaults{Key: "servers", Value: d.Server, List: true},
+		parser.EntryDefaults{Key: "database", Value: d.Database},
+	)
+}
+
+// Load carrega o arquivo com o Load do configparser (exerc02), decodificando no
+// Config deste exercício e validando com Validate.
+func Load(path string, opts ...LoadOption) (*Config, error) {
+	var cfg Config
+	err := parser.LoadTarget(path, parser.Target{
+		Version:  parser.Version{APIVersion: APIVersion, Kind: Kind},
+		Out:      &cfg,
+		Validate: func() ValidationErrors { return Validate(cfg) },
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadExampleConfig(t *testing.T) {
+	cfg, err := Load("../example_config.yaml")
+	if err != nil {
+		t.Fatalf("Erro ao carregar o exemplo: %v", err)
+	}
+	if len(cfg.Servers) != 1 || cfg.Servers[0].Port != 8080 {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+}
+
+func TestLoadEnvAndDefaults(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", `servers:
+  - name: app
+    host: ${APP_HOST}
+database:
+  host: localhost
+  user: ${DB_USER:-admin}
+`)
+	env := func(name string) (string, bool) {
+		if name == "APP_HOST" {
+			return "app.local", true
+		}
+		return "", false
+	}
+
+	cfg, err := Load(path, WithLookupEnv(env), WithDefaults(Defaults{
+		Server:   ServerConfig{Port: 8080},
+		Database: DatabaseConfig{Port: 5432},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Servers[0].Host != "app.local" || cfg.Servers[0].Port != 8080 {
+		t.Errorf("Servidor inesperado: %+v", cfg.Servers[0])
+	}
+	if cfg.Database.User != "admin" || cfg.Database.Port != 5432 {
+		t.Errorf("Banco de dados inesperado: %+v", cfg.Database)
+	}
+
+	var errs ValidationErrors
+	if _, err := Load(path); !errors.As(err, &errs) {
+		t.Errorf("Variável ausente deveria gerar ValidationErrors, obtido %v", err)
+	}
+}
+
+func TestLoadStrictAndValidation(t *testing.T) {
+	path := writeTestFile(t, "config.json", `{"servers": [{"name": "app", "hots": "x"}]}`)
+
+	if _, err := Load(path); err == nil {
+		t.Error("Campo desconhecido deveria falhar em modo estrito")
+	}
+
+	_, err := Load(path, WithStrict(false))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Esperado ValidationErrors, obtido %v", err)
+	}
+
+	if _, err := Load(path, WithStrict(false), WithValidation(false)); err != nil {
+		t.Errorf("Sem validação o arquivo deveria ser carregado: %v", err)
+	}
+}
+
+func TestLoadVersionHeader(t *testing.T) {
+	body := "servers:\n  - name: app\n    host: localhost\n    port: 80\ndatabase:\n  host: localhost\n  port: 5432\n  user: admin\n"
+
+	v1 := writeTestFile(t, "v1.yaml", "apiVersion: configparser/v1\nkind: Config\n"+body)
+	if _, err := Load(v1); err != nil {
+		t.Errorf("Cabeçalho configparser/v1 deveria ser aceito: %v", err)
+	}
+
+	v2 := writeTestFile(t, "v2.yaml", "apiVersion: configparser/v2\nkind: Config\n"+body)
+	if _, err := Load(v2); err == nil {
+		t.Error("Era esperado erro para configparser/v2")
+	}
+}
+
+func TestLoadEnvCannotChangeStructure(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", `servers:
+  - name: ${APP_NAME}
+    host: localhost
+    port: 80
+database:
+  host: localhost
+  port: 5432
+  user: admin
+`)
+	env := func(name string) (string, bool) {
+		return "app\n  - name: injected\n    host: evil: {x}", true
+	}
+
+	cfg, err := Load(path, WithLookupEnv(env))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Servers) != 1 || cfg.Servers[0].Name != "app\n  - name: injected\n    host: evil: {x}" {
+		t.Errorf("O valor da variável deveria continuar sendo um único texto: %+v", cfg.Servers)
+	}
+}
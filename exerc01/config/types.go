@@ -1,5 +1,7 @@
 package config
 
+import "strconv"
+
 type ServerConfig struct {
 	Name     string `json:"name" yaml:"name"`
 	Host     string `json:"host" yaml:"host"`
@@ -8,7 +10,7 @@ type ServerConfig struct {
 }
 
 func (s ServerConfig) String() string {
-	return s.Name + " at " + s.Host + ":" + string(rune(s.Port))
+	return s.Name + " at " + s.Host + ":" + strconv.Itoa(s.Port)
 }
 
 type DatabaseConfig struct {
@@ -19,7 +21,16 @@ type DatabaseConfig struct {
 }
 
 
+// APIVersion e Kind identificam o formato lido por este exercício no cabeçalho
+// opcional dos arquivos de configuração.
+const (
+	APIVersion = "configparser/v1"
+	Kind       = "Config"
+)
+
 type Config struct {
-	Servers  []ServerConfig   `json:"servers" yaml:"servers"`
-	Database DatabaseConfig   `json:"database" yaml:"database"`
+	APIVersion string           `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Kind       string           `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Servers    []ServerConfig   `json:"servers" yaml:"servers"`
+	Database   DatabaseConfig   `json:"database" yaml:"database"`
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+
+	parser "configparser-exerc02/config"
+)
+
+// Os tipos de validação são os do configparser (exerc02), para que os exercícios
+// reportem problemas no mesmo formato.
+type (
+	Severity         = parser.Severity
+	ValidationError  = parser.ValidationError
+	ValidationErrors = parser.ValidationErrors
+)
+
+const (
+	SeverityError   = parser.SeverityError
+	SeverityWarning = parser.SeverityWarning
+)
+
+func required(errs *ValidationErrors, path string, missing bool) {
+	if missing {
+		*errs = append(*errs, ValidationError{Path: path, Rule: "required", Message: "campo obrigatório ausente", Severity: SeverityError})
+	}
+}
+
+func port(errs *ValidationErrors, path string, port int) {
+	if port == 0 {
+		required(errs, path, true)
+		return
+	}
+	if port < 1 || port > 65535 {
+		*errs = append(*errs, ValidationError{Path: path, Rule: "port-range", Message: fmt.Sprintf("porta %d fora do intervalo 1-65535", port), Severity: SeverityError})
+	}
+}
+
+// Validate aplica as regras de validação e retorna todos os problemas encontrados.
+func Validate(cfg Config) ValidationErrors {
+	var errs ValidationErrors
+
+	for i, server := range cfg.Servers {
+		path := fmt.Sprintf("servers[%d]", i)
+		required(&errs, path+".name", server.Name == "")
+		required(&errs, path+".host", server.Host == "")
+		port(&errs, path+".port", server.Port)
+		if server.Replicas < 0 {
+			errs = append(errs, ValidationError{Path: path + ".replicas", Rule: "min", Message: "replicas não pode ser negativo", Severity: SeverityError})
+		}
+	}
+
+	db := cfg.Database
+	required(&errs, "database.host", db.Host == "")
+	port(&errs, "database.port", db.Port)
+	required(&errs, "database.user", db.User == "")
+
+	return errs
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestValidateValidConfig(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{{
+			Name:     "app-server",
+			Host:     "localhost",
+			Port:     8080,
+			Replicas: 3,
+		}},
+		Database: DatabaseConfig{
+			Host: "localhost",
+			Port: 5432,
+			User: "admin",
+		},
+	}
+
+	if errs := Validate(cfg); len(errs) != 0 {
+		t.Errorf("Não eram esperados erros de validação: %v", errs)
+	}
+}
+
+func TestValidateFieldPaths(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "ok", Host: "localhost", Port: 8080},
+			{Name: "sem-porta", Host: "localhost"},
+			{Name: "porta-invalida", Host: "localhost", Port: 70000},
+		},
+		Database: DatabaseConfig{Host: "localhost", Port: 5432},
+	}
+
+	errs := Validate(cfg)
+	if !errs.HasErrors() {
+		t.Fatal("Eram esperados erros de validação")
+	}
+
+	expected := map[string]string{
+		"servers[1].port": "required",
+		"servers[2].port": "port-range",
+		"database.user":   "required",
+	}
+	for _, e := range errs {
+		if rule, ok := expected[e.Path]; ok && rule == e.Rule {
+			delete(expected, e.Path)
+		}
+	}
+	for path, rule := range expected {
+		t.Errorf("Erro %s não reportado para %s", rule, path)
+	}
+}
@@ -2,9 +2,17 @@ module github.com/matheusmacan/configparser
 
 go 1.24.5
 
+require (
+	configparser-exerc02 v0.0.0
+	github.com/spf13/cobra v1.10.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.10.2 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
-	gopkg.in/yaml.v3 v3.0.1
 )
+
+// O pacote config do exerc02 é compartilhado entre os exercícios.
+replace configparser-exerc02 => ../exerc02
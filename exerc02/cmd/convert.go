@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var convertFile string
+var convertFrom string
+var convertTo string
+var convertOutput string
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Converte o arquivo de configuração entre YAML, JSON e TOML",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := readInput(convertFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+
+		from := config.DetectFormat(convertFile, data)
+		if convertFrom != "" {
+			if from, err = config.ParseFormat(convertFrom); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		to, err := config.ParseFormat(convertTo)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		docs, err := parseDocumentsAs(convertFile, data, from)
+		for i := 0; err == nil && i < len(docs); i++ {
+			err = config.DecodeNode(convertFile, docs[i], &config.Config{})
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+			os.Exit(1)
+		}
+		if len(docs) > 1 && to != config.FormatYAML {
+			fmt.Printf("O arquivo contém %d documentos; %s representa apenas um.\n", len(docs), to)
+			os.Exit(1)
+		}
+
+		if to == config.FormatYAML && from != config.FormatYAML {
+			for _, root := range docs {
+				config.NormalizeStyle(root)
+			}
+		}
+		out, err := encodeDocuments(docs, to)
+		if err != nil {
+			fmt.Println("Erro ao converter o arquivo:", err)
+			os.Exit(1)
+		}
+
+		if convertOutput == "" {
+			os.Stdout.Write(out)
+			return
+		}
+		if err := os.WriteFile(convertOutput, out, 0644); err != nil {
+			fmt.Println("Erro ao escrever o arquivo:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// parseDocumentsAs lê todos os documentos YAML do fluxo; os demais formatos têm um só.
+func parseDocumentsAs(filename string, data []byte, format config.Format) ([]*yaml.Node, error) {
+	if format == config.FormatYAML {
+		return config.ParseDocuments(filename, data)
+	}
+	root, err := config.ParseNodeAs(filename, data, format)
+	if err != nil {
+		return nil, err
+	}
+	return []*yaml.Node{root}, nil
+}
+
+// encodeDocuments serializa os documentos, separando-os com --- em YAML.
+func encodeDocuments(docs []*yaml.Node, format config.Format) ([]byte, error) {
+	var out []byte
+	for i, root := range docs {
+		doc, err := config.Encode(root, format)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			out = append(out, "---\n"...)
+		}
+		out = append(out, doc...)
+	}
+	return out, nil
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+	convertCmd.Flags().StringVarP(&convertFile, "file", "f", "", "Arquivo de configuração de origem (- para stdin)")
+	convertCmd.Flags().StringVar(&convertFrom, "from", "", "Formato de origem: yaml, json ou toml (padrão: detectado)")
+	convertCmd.Flags().StringVar(&convertTo, "to", "", "Formato de destino: yaml, json ou toml")
+	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Arquivo de saída (padrão: stdout)")
+	convertCmd.MarkFlagRequired("file")
+	convertCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var diffOutput string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <antigo> <novo>",
+	Short: "Compara semanticamente dois arquivos de configuração",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if diffOutput != "text" && diffOutput != "json" {
+			fmt.Printf("formato de saída desconhecido %q (use text ou json)\n", diffOutput)
+			os.Exit(1)
+		}
+
+		oldCfg := decodeConfig(args[:1])
+		newCfg := decodeConfig(args[1:])
+		changes := config.Diff(oldCfg, newCfg)
+
+		if diffOutput == "json" {
+			if changes == nil {
+				changes = []config.Change{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(changes)
+			return
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("Nenhuma diferença encontrada.")
+			return
+		}
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "text", "Formato de saída: text ou json")
+	diffCmd.Flags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	diffCmd.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+}
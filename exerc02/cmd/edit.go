@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var editFile string
+var editDocument int
+var editInPlace bool
+var getOutput string
+
+var getCmd = &cobra.Command{
+	Use:   "get <caminho>",
+	Short: "Mostra um valor do arquivo, ex.: servers[name=httpbin-1].port",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := config.ParseFormat(getOutput)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		docs, _ := readEditFile()
+		n, err := config.Lookup(docs[editDocument], args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if n.Kind == yaml.ScalarNode {
+			fmt.Println(n.Value)
+			return
+		}
+		out, err := config.Encode(n, format)
+		if err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(out)
+	},
+}
+
+var setCmd = &cobra.Command{
+	Use:   "set <caminho> <valor>",
+	Short: "Altera um valor do arquivo preservando comentários e a ordem dos campos",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if editInPlace && editFile == config.StdinPath {
+			fmt.Println("--in-place não pode ser usado com a entrada padrão")
+			os.Exit(1)
+		}
+
+		docs, format := readEditFile()
+		value := config.ParseValue(args[1])
+		if err := config.CheckValue(args[0], value); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Println("Valor inválido para", args[0])
+			os.Exit(1)
+		}
+		if err := config.SetPath(docs[editDocument], args[0], value); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		out, err := encodeDocuments(docs, format)
+		if err != nil {
+			fmt.Println("Erro ao gerar o arquivo:", err)
+			os.Exit(1)
+		}
+		if !editInPlace {
+			os.Stdout.Write(out)
+			return
+		}
+		info, err := os.Stat(editFile)
+		if err == nil {
+			err = os.WriteFile(editFile, out, info.Mode().Perm())
+		}
+		if err != nil {
+			fmt.Println("Erro ao escrever o arquivo:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func readEditFile() ([]*yaml.Node, config.Format) {
+	data, err := readInput(editFile)
+	if err != nil {
+		fmt.Println("Erro ao ler o arquivo:", err)
+		os.Exit(1)
+	}
+	format := config.DetectFormat(editFile, data)
+	docs, err := parseDocumentsAs(editFile, data, format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+		os.Exit(1)
+	}
+	if editDocument < 0 || editDocument >= len(docs) {
+		fmt.Printf("Documento %d inexistente; o arquivo contém %d documento(s)\n", editDocument, len(docs))
+		os.Exit(1)
+	}
+	return docs, format
+}
+
+func init() {
+	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(setCmd)
+	for _, c := range []*cobra.Command{getCmd, setCmd} {
+		c.Flags().StringVarP(&editFile, "file", "f", "", "Arquivo de configuração (- para stdin)")
+		c.Flags().IntVar(&editDocument, "document", 0, "Documento do fluxo YAML (---), a partir de 0")
+		c.MarkFlagRequired("file")
+	}
+	getCmd.Flags().StringVarP(&getOutput, "output", "o", "yaml", "Formato de objetos e listas: yaml, json ou toml")
+	setCmd.Flags().BoolVarP(&editInPlace, "in-place", "i", false, "Grava o resultado no próprio arquivo em vez da saída padrão")
+}
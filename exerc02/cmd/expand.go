@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var expandPorts string
+var expandOutput string
+
+var expandCmd = &cobra.Command{
+	Use:   "expand",
+	Short: "Gera uma instância por réplica (name-1..name-N), cada uma com sua porta",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := checkOutputFormat(expandOutput); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		var ports config.PortRange
+		if expandPorts != "" {
+			var err error
+			if ports, err = config.ParsePortRange(expandPorts); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+
+		cfg, err := config.Expand(loadConfig(filePaths), ports)
+		if err != nil {
+			exitLoadError(err)
+		}
+		if err := renderConfig(os.Stdout, cfg, expandOutput); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(expandCmd)
+	expandCmd.Flags().StringArrayVarP(&filePaths, "file", "f", nil, "Arquivo de configuração (YAML ou JSON, - para stdin); repita para aplicar overlays em ordem")
+	expandCmd.Flags().StringVar(&expandPorts, "ports", "", "Intervalo de portas das instâncias, ex.: 20000-20999 (padrão: portas seguintes à do servidor)")
+	expandCmd.Flags().StringVarP(&expandOutput, "output", "o", "yaml", "Formato de saída: yaml, json, table ou wide")
+	expandCmd.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+	expandCmd.Flags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	expandCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var importFile string
+var importOutput string
+var importColumns map[string]string
+var importDelimiter string
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Gera um arquivo de configuração a partir de outros formatos",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if importOutput != "yaml" && importOutput != "json" {
+			fmt.Printf("formato de saída desconhecido %q (use yaml ou json)\n", importOutput)
+			os.Exit(1)
+		}
+	},
+}
+
+var importComposeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Lê um docker-compose.yml e gera a configuração equivalente",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := readInput(importFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+		cfg, err := config.ImportCompose(importFile, data)
+		writeImported(cfg, err)
+	},
+}
+
+var importCSVCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Lê uma planilha CSV com um servidor por linha",
+	Long: `Lê uma planilha CSV com cabeçalho e um servidor por linha. As colunas são
+procuradas pelo nome do campo (name, host, port, protocol, healthcheck, replicas,
+tags) ou associadas com --columns, ex.: --columns host=IP,port=Porta.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len([]rune(importDelimiter)) != 1 {
+			fmt.Println("O delimitador deve ser um único caractere")
+			os.Exit(1)
+		}
+		data, err := readInput(importFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+		cfg, err := config.ImportCSV(importFile, bytes.NewReader(data), config.CSVOptions{Columns: importColumns, Comma: []rune(importDelimiter)[0]})
+		writeImported(cfg, err)
+	},
+}
+
+var importAnsibleCmd = &cobra.Command{
+	Use:   "ansible",
+	Short: "Lê um inventário do Ansible (INI ou YAML); os grupos viram tags",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := readInput(importFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+		cfg, err := config.ImportAnsible(importFile, data)
+		writeImported(cfg, err)
+	},
+}
+
+// writeImported grava a configuração importada e reporta as entradas que não
+// puderam ser convertidas.
+func writeImported(cfg config.Config, err error) {
+	var errs config.ValidationErrors
+	if err != nil && !errors.As(err, &errs) {
+		fmt.Println("Erro ao importar:", err)
+		os.Exit(1)
+	}
+	done, err := renderData(os.Stdout, cfg, importOutput)
+	if err == nil && !done {
+		err = fmt.Errorf("formato de saída desconhecido %q", importOutput)
+	}
+	if err != nil {
+		fmt.Println("Erro ao gerar a saída:", err)
+		os.Exit(1)
+	}
+	if problems := config.Validate(cfg); len(problems) > 0 {
+		fmt.Fprintln(os.Stderr, "Campos a completar antes de usar o arquivo:")
+		for _, e := range problems {
+			fmt.Fprintln(os.Stderr, " -", e)
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintln(os.Stderr, "Entradas não importadas:")
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, " -", e)
+		}
+		os.Exit(exitInvalidConfig)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importComposeCmd, importCSVCmd, importAnsibleCmd)
+	importCmd.PersistentFlags().StringVarP(&importFile, "file", "f", "", "Arquivo de entrada (- para stdin)")
+	importCmd.PersistentFlags().StringVarP(&importOutput, "output", "o", "yaml", "Formato de saída: yaml ou json")
+	importCmd.MarkPersistentFlagRequired("file")
+	importCSVCmd.Flags().StringToStringVar(&importColumns, "columns", nil, "Associação campo=coluna, ex.: name=Servidor,host=IP")
+	importCSVCmd.Flags().StringVar(&importDelimiter, "delimiter", ",", "Separador das colunas")
+}
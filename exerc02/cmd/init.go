@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var initOutput string
+var initFormat string
+var initForce bool
+var initServers []string
+var initDatabase string
+var initWebsites []string
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Cria um arquivo de configuração novo, com perguntas ou a partir das flags",
+	Long: `Cria um arquivo de configuração novo. Sem flags, pergunta pelos servidores,
+banco de dados e websites, validando cada resposta. Fora de um terminal, as
+entradas são informadas por flags no formato campo=valor:
+
+  init --server name=web,host=web.local,port=8080 --database user=admin \
+       --website name=GitHub,url=https://github.com -o config.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := initFormat
+		if format == "" {
+			format = "yaml"
+			if strings.EqualFold(filepath.Ext(initOutput), ".json") {
+				format = "json"
+			}
+		}
+		if format != "yaml" && format != "json" {
+			fmt.Printf("formato desconhecido %q (use yaml ou json)\n", format)
+			os.Exit(1)
+		}
+		if initOutput != config.StdinPath && !initForce {
+			if _, err := os.Stat(initOutput); err == nil {
+				fmt.Printf("O arquivo %s já existe; use --force para sobrescrever\n", initOutput)
+				os.Exit(1)
+			}
+		}
+
+		var cfg config.Config
+		if len(initServers) > 0 || initDatabase != "" || len(initWebsites) > 0 {
+			cfg = configFromSpecs()
+		} else {
+			if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+				fmt.Println("A entrada padrão não é um terminal; informe as entradas com --server, --database e --website")
+				os.Exit(1)
+			}
+			w := &config.Wizard{In: os.Stdin, Out: os.Stderr}
+			var err error
+			if cfg, err = w.Run(); err != nil {
+				fmt.Println("Erro:", err)
+				os.Exit(1)
+			}
+		}
+
+		if errs := config.Validate(cfg); errs.HasErrors() {
+			exitLoadError(errs)
+		}
+		var out bytes.Buffer
+		if _, err := renderData(&out, cfg, format); err != nil {
+			fmt.Println("Erro ao gerar o arquivo:", err)
+			os.Exit(1)
+		}
+		if initOutput == config.StdinPath {
+			os.Stdout.Write(out.Bytes())
+			return
+		}
+		if err := os.WriteFile(initOutput, out.Bytes(), 0644); err != nil {
+			fmt.Println("Erro ao escrever o arquivo:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Configuração gravada em %s\n", initOutput)
+	},
+}
+
+// configFromSpecs monta a configuração a partir de --server, --database e --website.
+func configFromSpecs() config.Config {
+	cfg := config.Config{APIVersion: config.APIVersionV2, Kind: config.KindConfig}
+	fail := func(flag, spec string, err error) {
+		fmt.Printf("--%s %q: %v\n", flag, spec, err)
+		os.Exit(1)
+	}
+	for _, spec := range initServers {
+		s, err := config.ParseServerSpec(spec)
+		if err != nil {
+			fail("server", spec, err)
+		}
+		cfg.Servers = append(cfg.Servers, s)
+	}
+	if initDatabase != "" {
+		db, err := config.ParseDatabaseSpec(initDatabase)
+		if err != nil {
+			fail("database", initDatabase, err)
+		}
+		cfg.Database = db
+	}
+	for _, spec := range initWebsites {
+		w, err := config.ParseWebsiteSpec(spec)
+		if err != nil {
+			fail("website", spec, err)
+		}
+		cfg.Website = append(cfg.Website, w)
+	}
+	return cfg
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().StringVarP(&initOutput, "output", "o", "config.yaml", "Arquivo de saída (- para a saída padrão)")
+	initCmd.Flags().StringVar(&initFormat, "format", "", "Formato do arquivo: yaml ou json (padrão: pela extensão)")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Sobrescreve o arquivo de saída se ele existir")
+	initCmd.Flags().StringArrayVar(&initServers, "server", nil, "Servidor no formato name=...,host=...,port=...,protocol=...,healthcheck=...,replicas=... (repetível)")
+	initCmd.Flags().StringVar(&initDatabase, "database", "", "Banco de dados no formato host=...,port=...,user=...,password=...")
+	initCmd.Flags().StringArrayVar(&initWebsites, "website", nil, "Website no formato name=...,url=...,max_response_time=... (repetível)")
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"text/tabwriter"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var lintConfigPath string
+var lintOutput string
+var lintListRules bool
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Verifica a configuração com regras entre entradas (nomes, portas, unidades...)",
+	Run: func(cmd *cobra.Command, args []string) {
+		if lintListRules {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "REGRA\tDESCRIÇÃO")
+			for _, rule := range config.LintRules {
+				fmt.Fprintf(w, "%s\t%s\n", rule.Name, rule.Description)
+			}
+			w.Flush()
+			return
+		}
+		if len(filePaths) == 0 {
+			fmt.Println(`a flag "file" é obrigatória`)
+			os.Exit(1)
+		}
+		if lintOutput != "text" && lintOutput != "json" {
+			fmt.Printf("formato de saída desconhecido %q (use text ou json)\n", lintOutput)
+			os.Exit(1)
+		}
+
+		lc, err := config.LoadLintConfig(lintConfigPath)
+		if err != nil {
+			// Sem .configlint.yaml no diretório atual, todas as regras ficam habilitadas.
+			if !errors.Is(err, fs.ErrNotExist) || cmd.Flags().Changed("config") {
+				fmt.Println("Erro ao ler a configuração do lint:", err)
+				os.Exit(1)
+			}
+		}
+
+		cfg := decodeConfig(filePaths)
+		problems := config.Lint(cfg, lc)
+
+		if lintOutput == "json" {
+			if problems == nil {
+				problems = config.ValidationErrors{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(problems)
+		} else if len(problems) == 0 {
+			fmt.Println("Nenhum problema encontrado.")
+		} else {
+			for _, p := range problems {
+				fmt.Println(p.Error())
+			}
+		}
+
+		if problems.HasErrors() {
+			os.Exit(exitInvalidConfig)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringArrayVarP(&filePaths, "file", "f", nil, "Arquivo de configuração (YAML ou JSON, - para stdin); repita para aplicar overlays em ordem")
+	lintCmd.Flags().StringVar(&lintConfigPath, "config", config.LintConfigFile, "Arquivo que habilita/desabilita as regras")
+	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", "text", "Formato de saída: text ou json")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "Lista as regras disponíveis")
+	lintCmd.Flags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	lintCmd.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateFile string
+var migrateInPlace bool
+var migrateList bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Atualiza o arquivo de configuração para a versão mais nova do formato",
+	Run: func(cmd *cobra.Command, args []string) {
+		if migrateList {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "APIVERSION\tKIND\tDESCRIÇÃO")
+			for _, v := range config.Versions {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", v.APIVersion, v.Kind, v.Description)
+			}
+			w.Flush()
+			return
+		}
+		if migrateFile == "" {
+			fmt.Println(`a flag "file" é obrigatória`)
+			os.Exit(1)
+		}
+		if migrateInPlace && migrateFile == config.StdinPath {
+			fmt.Println("--in-place não pode ser usado com a entrada padrão")
+			os.Exit(1)
+		}
+
+		data, err := readInput(migrateFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+		format := config.DetectFormat(migrateFile, data)
+		docs, err := parseDocumentsAs(migrateFile, data, format)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+			os.Exit(1)
+		}
+
+		for _, root := range docs {
+			from, to, err := config.Migrate(root)
+			if err == nil && to.Kind == config.KindConfig {
+				err = config.DecodeNode(migrateFile, root, &config.Config{})
+			}
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Println("Erro ao migrar o arquivo de configuração.")
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "%s → %s\n", from, to)
+		}
+
+		out, err := encodeDocuments(docs, format)
+		if err != nil {
+			fmt.Println("Erro ao gerar o arquivo:", err)
+			os.Exit(1)
+		}
+		if !migrateInPlace {
+			os.Stdout.Write(out)
+			return
+		}
+		info, err := os.Stat(migrateFile)
+		if err == nil {
+			err = os.WriteFile(migrateFile, out, info.Mode().Perm())
+		}
+		if err != nil {
+			fmt.Println("Erro ao escrever o arquivo:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVarP(&migrateFile, "file", "f", "", "Arquivo de configuração (- para stdin)")
+	migrateCmd.Flags().BoolVarP(&migrateInPlace, "in-place", "i", false, "Grava o resultado no próprio arquivo em vez da saída padrão")
+	migrateCmd.Flags().BoolVar(&migrateList, "list", false, "Lista as versões conhecidas do formato")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"configparser-exerc02/config"
+)
+
+var healthInterval time.Duration
+var healthHeartbeat time.Duration
+var healthTimeout time.Duration
+var healthFailures int
+var healthOutput string
+
+// monitorHealth verifica os servidores continuamente, exibindo só as mudanças de
+// estado e os heartbeats, até o processo ser interrompido.
+func monitorHealth(cfg config.Config) {
+	if healthOutput != "table" && healthOutput != "json" && healthOutput != "yaml" {
+		fmt.Printf("formato de saída desconhecido %q (use table, json ou yaml)\n", healthOutput)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	events := config.Monitor(ctx, cfg.Servers, config.MonitorOptions{
+		Interval:  healthInterval,
+		Heartbeat: healthHeartbeat,
+		Failures:  healthFailures,
+		Checker:   httpHealthCheck,
+	})
+	for ev := range events {
+		if err := renderMonitorEvent(os.Stdout, ev, healthOutput); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// hasServerInterval indica se algum servidor define interval, o que também
+// coloca o health em modo contínuo.
+func hasServerInterval(cfg config.Config) bool {
+	for _, s := range cfg.Servers {
+		if s.Interval != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// httpHealthCheck considera o servidor saudável quando o healthcheck responde 200.
+func httpHealthCheck(ctx context.Context, server config.ServerConfig) config.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", server.HealthURL(), nil)
+	if err != nil {
+		return config.CheckResult{Error: err.Error()}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return config.CheckResult{Error: err.Error()}
+	}
+	resp.Body.Close()
+
+	result := config.CheckResult{Healthy: resp.StatusCode == http.StatusOK, StatusCode: resp.StatusCode}
+	if !result.Healthy {
+		result.Error = "status " + resp.Status
+	}
+	return result
+}
+
+func init() {
+	testHealthStatus.Flags().DurationVar(&healthInterval, "interval", 0, "Continua executando e verifica os servidores a cada intervalo (o interval de cada servidor tem precedência; sem a flag, servidores sem interval usam 30s)")
+	testHealthStatus.Flags().DurationVar(&healthHeartbeat, "heartbeat", 5*time.Minute, "Intervalo entre os resumos de estado no modo contínuo (0 desliga)")
+	testHealthStatus.Flags().DurationVar(&healthTimeout, "timeout", 5*time.Second, "Tempo limite de cada verificação no modo contínuo")
+	testHealthStatus.Flags().IntVar(&healthFailures, "failures", 3, "Falhas seguidas até o servidor ser considerado down")
+	testHealthStatus.Flags().StringVarP(&healthOutput, "output", "o", "table", "Formato dos eventos no modo contínuo: table, json ou yaml")
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"configparser-exerc02/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+var outputFormat string
+
+var outputFormats = []string{"table", "wide", "json", "yaml"}
+
+func checkOutputFormat(format string) error {
+	for _, f := range outputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("formato de saída desconhecido %q (use table, wide, json ou yaml)", format)
+}
+
+// renderData serializa v em JSON ou YAML; retorna false para formatos tabulares.
+func renderData(w io.Writer, v any, format string) (bool, error) {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return true, enc.Encode(v)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		defer enc.Close()
+		return true, enc.Encode(v)
+	}
+	return false, nil
+}
+
+func renderConfig(w io.Writer, cfg config.Config, format string) error {
+	cfg = config.Redact(cfg)
+	if done, err := renderData(w, cfg, format); done {
+		return err
+	}
+
+	fmt.Fprintln(w, "SERVIDORES")
+	if err := renderServerTable(w, cfg.Servers, format == "wide"); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nBANCO DE DADOS")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "HOST\tPORT\tUSER")
+	fmt.Fprintf(tw, "%s\t%d\t%s\n", cfg.Database.Host, cfg.Database.Port, cfg.Database.User)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nWEBSITES")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tURL\tMAX_RESPONSE_TIME")
+	for _, website := range cfg.Website {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", website.Name, website.Url, website.MaxResponseTime)
+	}
+	return tw.Flush()
+}
+
+// renderConfigs exibe as configurações de cada documento: uma lista em JSON, um
+// fluxo de documentos em YAML e uma tabela por documento nos formatos tabulares.
+func renderConfigs(w io.Writer, cfgs []config.Config, format string) error {
+	redacted := make([]config.Config, len(cfgs))
+	for i, cfg := range cfgs {
+		redacted[i] = config.Redact(cfg)
+	}
+	switch format {
+	case "json":
+		_, err := renderData(w, redacted, format)
+		return err
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		for _, cfg := range redacted {
+			if err := enc.Encode(cfg); err != nil {
+				return err
+			}
+		}
+		return enc.Close()
+	}
+	for i, cfg := range cfgs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "DOCUMENTO %d\n\n", i+1)
+		if err := renderConfig(w, cfg, format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderEvent exibe um evento do modo --watch: uma linha JSON por evento em json,
+// um documento por evento em yaml e texto nos formatos tabulares.
+func renderEvent(w io.Writer, ev config.Event, format string) error {
+	switch format {
+	case "json":
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		fmt.Fprintln(w, "---")
+		_, err := renderData(w, ev, format)
+		return err
+	}
+
+	timestamp := ev.Time.Format("15:04:05")
+	switch ev.Type {
+	case config.EventLoaded:
+		fmt.Fprintln(w, "Configuração carregada com sucesso:")
+		return renderConfig(w, *ev.Config, format)
+	case config.EventInvalid:
+		for _, e := range ev.Errors {
+			fmt.Fprintln(w, e.Error())
+		}
+		if ev.Error != "" {
+			fmt.Fprintln(w, ev.Error)
+		}
+		fmt.Fprintf(w, "[%s] Configuração inválida; mantendo a última versão válida.\n", timestamp)
+		return nil
+	}
+	fmt.Fprintf(w, "\n[%s] Configuração alterada:\n", timestamp)
+	if len(ev.Changes) == 0 {
+		fmt.Fprintln(w, "Configuração válida novamente, sem diferenças.")
+	}
+	for _, c := range ev.Changes {
+		fmt.Fprintln(w, c)
+	}
+	return nil
+}
+
+// renderOrigins exibe o valor efetivo e a origem de cada campo.
+func renderOrigins(w io.Writer, origins []config.Origin, format string) error {
+	if done, err := renderData(w, origins, format); done {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tVALUE\tSOURCE")
+	for _, o := range origins {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", o.Path, o.Value, o.Source)
+	}
+	return tw.Flush()
+}
+
+func renderServers(w io.Writer, servers []config.ServerConfig, format string) error {
+	if done, err := renderData(w, servers, format); done {
+		return err
+	}
+	return renderServerTable(w, servers, format == "wide")
+}
+
+func renderServerTable(w io.Writer, servers []config.ServerConfig, wide bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if wide {
+		fmt.Fprintln(tw, "NAME\tHOST\tPORT\tREPLICAS\tPROTOCOL\tHEALTHCHECK\tLABELS")
+	} else {
+		fmt.Fprintln(tw, "NAME\tHOST\tPORT\tREPLICAS")
+	}
+	for _, s := range servers {
+		if wide {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", s.Name, s.Host, s.Port, s.Replicas, s.Protocol, s.Healthcheck, formatLabels(s.Tags, s.Labels))
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n", s.Name, s.Host, s.Port, s.Replicas)
+		}
+	}
+	return tw.Flush()
+}
+
+// formatLabels junta tags e labels (chave=valor, em ordem) para as tabelas.
+func formatLabels(tags []string, labels map[string]string) string {
+	parts := slices.Clone(tags)
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		parts = append(parts, k+"="+labels[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+// renderValidate exibe os problemas da validação e, quando verificados, o
+// resultado de DNS/TCP de cada entrada.
+func renderValidate(w io.Writer, report validateReport, format string) error {
+	if done, err := renderData(w, report, format); done {
+		return err
+	}
+	if len(report.Problems) == 0 {
+		fmt.Fprintln(w, "Nenhum problema encontrado.")
+	}
+	for _, p := range report.Problems {
+		fmt.Fprintln(w, p.Error())
+	}
+	if report.Reachability == nil {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tNAME\tHOST\tPORT\tADDRESSES\tTCP\tSTATUS")
+	for _, r := range report.Reachability {
+		tcp := "-"
+		if r.Reachable != nil {
+			tcp = map[bool]string{true: "ok", false: "falhou"}[*r.Reachable]
+		}
+		status := "ok"
+		if !r.OK() {
+			status = r.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n", r.Path, r.Name, r.Host, r.Port, strings.Join(r.Addresses, ","), tcp, status)
+	}
+	return tw.Flush()
+}
+
+// renderMonitorEvent exibe uma transição ou um heartbeat do health --interval.
+func renderMonitorEvent(w io.Writer, ev config.MonitorEvent, format string) error {
+	switch format {
+	case "json":
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		fmt.Fprintln(w, "---")
+		_, err := renderData(w, ev, format)
+		return err
+	}
+
+	timestamp := ev.Time.Format("15:04:05")
+	if ev.Type == config.EventHeartbeat {
+		counts := map[config.HealthState]int{}
+		for _, s := range ev.Servers {
+			counts[s.State]++
+		}
+		_, err := fmt.Fprintf(w, "[%s] heartbeat: %d up, %d degraded, %d down, %d unknown\n", timestamp,
+			counts[config.StateUp], counts[config.StateDegraded], counts[config.StateDown], counts[config.StateUnknown])
+		return err
+	}
+	detail := ev.Error
+	if detail == "" && ev.StatusCode != 0 {
+		detail = fmt.Sprintf("status %d", ev.StatusCode)
+	}
+	_, err := fmt.Fprintf(w, "[%s] %s: %s → %s (%s)\n", timestamp, ev.Server, ev.From, ev.To, detail)
+	return err
+}
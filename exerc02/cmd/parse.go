@@ -3,16 +3,20 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"slices"
 	"sync"
+	"syscall"
 	"time"
 
 	"configparser-exerc02/config"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 type HealthResult struct {
@@ -29,24 +33,60 @@ type ResponseResult struct {
 	Timestamp string `json:"timestamp"`
 }
 
-var filePath string
+var filePaths []string
+var schemaPath string
+var allowMissingEnv bool
+var splitDocuments bool
+var watchFile bool
+var watchInterval time.Duration
+var selectorExpr string
+var showResolved bool
+var nameFilters []string
 
 var parseCmd = &cobra.Command{
 	Use:   "parse",
 	Short: "Faz o parse de um arquivo de configuração YAML ou JSON",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
+		if err := checkOutputFormat(outputFormat); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		if watchFile {
+			watchConfig()
+			return
+		}
+		if showResolved {
+			if splitDocuments {
+				fmt.Println("--resolved não pode ser usado com --split")
+				os.Exit(1)
+			}
+			var origins []config.Origin
+			loadConfig(filePaths, config.WithOrigins(func(o config.Origin) { origins = append(origins, o) }))
+			if err := renderOrigins(os.Stdout, origins, outputFormat); err != nil {
+				fmt.Println("Erro ao gerar a saída:", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if splitDocuments {
+			cfgs := loadConfigs(filePaths)
+			if outputFormat == "table" || outputFormat == "wide" {
+				fmt.Println("Configuração carregada com sucesso:")
+			}
+			if err := renderConfigs(os.Stdout, cfgs, outputFormat); err != nil {
+				fmt.Println("Erro ao gerar a saída:", err)
+				os.Exit(1)
+			}
 			return
 		}
 
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg)
-		} else {
-			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+		cfg := loadConfig(filePaths)
+		if outputFormat == "table" || outputFormat == "wide" {
+			fmt.Println("Configuração carregada com sucesso:")
+		}
+		if err := renderConfig(os.Stdout, cfg, outputFormat); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
 			os.Exit(1)
 		}
 	},
@@ -56,31 +96,22 @@ var responseCheck = &cobra.Command{
 	Use:   "response",
 	Short: "Testa o endpoint de tempo de resposta",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
-		}
-
 		var wg sync.WaitGroup
 
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			webservers := make(chan config.WebsiteConfig, len(cfg.Website))
-			for w := 1; w <= 10; w++ {
-				wg.Add(1)
-				go AsyncResponseTime(&wg, webservers, w)
-			}
+		cfg := filterConfig(loadConfig(filePaths))
 
-			for _, webserver := range cfg.Website {
-				webservers <- webserver
-			}
+		webservers := make(chan config.WebsiteConfig, len(cfg.Website))
+		for w := 1; w <= 10; w++ {
+			wg.Add(1)
+			go AsyncResponseTime(&wg, webservers, w)
+		}
 
-			close(webservers)
-			wg.Wait()
+		for _, webserver := range cfg.Website {
+			webservers <- webserver
 		}
 
+		close(webservers)
+		wg.Wait()
 	},
 }
 
@@ -88,30 +119,26 @@ var testHealthStatus = &cobra.Command{
 	Use:   "health",
 	Short: "Testa o endpoint de health check",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
-		}
-
 		var wg sync.WaitGroup
 
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			servers := make(chan config.ServerConfig, len(cfg.Servers))
-			for w := 1; w <= 10; w++ {
-				wg.Add(1)
-				go AsyncHealthCheck(&wg, servers, w)
-			}
+		cfg := filterConfig(loadConfig(filePaths))
+		if healthInterval > 0 || hasServerInterval(cfg) {
+			monitorHealth(cfg)
+			return
+		}
 
-			for _, server := range cfg.Servers {
-				servers <- server
-			}
+		servers := make(chan config.ServerConfig, len(cfg.Servers))
+		for w := 1; w <= 10; w++ {
+			wg.Add(1)
+			go AsyncHealthCheck(&wg, servers, w)
+		}
 
-			close(servers)
-			wg.Wait()
+		for _, server := range cfg.Servers {
+			servers <- server
 		}
+
+		close(servers)
+		wg.Wait()
 	},
 }
 
@@ -119,39 +146,123 @@ var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Imprimi somente os servidores",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
+		if err := checkOutputFormat(outputFormat); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg.Servers)
-		} else {
-			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+		cfg := filterConfig(loadConfig(filePaths))
+		if outputFormat == "table" || outputFormat == "wide" {
+			fmt.Println("Configuração carregada com sucesso:")
+		}
+		if err := renderServers(os.Stdout, cfg.Servers, outputFormat); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
 			os.Exit(1)
 		}
 	},
 }
 
-func validateConfig(cfg config.Config) {
-	for i, server := range cfg.Servers {
-		if server.Name == "" || server.Host == "" || server.Port == 0 {
-			fmt.Printf("Servidor #%d com campos obrigatórios ausentes\n", i)
+// loadConfig carrega os arquivos de configuração com config.Load usando as flags
+// comuns dos comandos, encerrando o processo em caso de erro.
+func loadConfig(paths []string, opts ...config.LoadOption) config.Config {
+	if requireSignature {
+		// A assinatura é conferida no conteúdo lido por Load, não em uma leitura à parte.
+		opts = append(opts, config.WithPublicKey(readPublicKey()))
+	}
+	cfg, err := config.Load(paths[0], append(loadOptions(paths), opts...)...)
+	if err != nil {
+		exitLoadError(err)
+	}
+	return *cfg
+}
+
+// filterConfig mantém somente os servidores e websites selecionados por
+// --selector e --name.
+func filterConfig(cfg config.Config) config.Config {
+	filter, err := config.NewFilter(selectorExpr, nameFilters)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return filter.Apply(cfg)
+}
+
+// watchConfig recarrega a configuração sempre que os arquivos mudam, exibindo um
+// evento a cada recarga, até o processo ser interrompido.
+func watchConfig() {
+	if splitDocuments || slices.Contains(filePaths, config.StdinPath) {
+		fmt.Println("--watch não pode ser usado com --split nem com a entrada padrão")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for ev := range config.Watch(ctx, filePaths[0], watchInterval, loadOptions(filePaths)...) {
+		if err := renderEvent(os.Stdout, ev, outputFormat); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
+			os.Exit(1)
 		}
 	}
-	db := cfg.Database
-	if db.Host == "" || db.Port == 0 || db.User == "" {
-		fmt.Println("Configuração do banco de dados com campos obrigatórios ausentes")
+}
+
+// loadConfigs é como loadConfig, mas retorna uma configuração por documento YAML.
+func loadConfigs(paths []string) []config.Config {
+	cfgs, err := config.LoadAll(paths[0], loadOptions(paths)...)
+	if err != nil {
+		exitLoadError(err)
 	}
+	return cfgs
+}
 
-	for i, website := range cfg.Website {
-		if website.Name == "" || website.Url == "" || website.MaxResponseTime == 0 {
-			fmt.Printf("Website #%d com campos obrigatórios ausentes\n", i)
+// decodeConfig é como loadConfig, mas não aplica as regras de validação.
+func decodeConfig(paths []string) config.Config {
+	return loadConfig(paths, config.WithValidation(false))
+}
+
+func loadOptions(paths []string) []config.LoadOption {
+	opts := []config.LoadOption{
+		config.WithOverlays(paths[1:]...),
+		config.WithAllowMissingEnv(allowMissingEnv),
+		config.WithWarnings(func(w config.ValidationError) {
+			fmt.Fprintln(os.Stderr, w.Error())
+		}),
+	}
+	if keyFile != "" {
+		opts = append(opts, config.WithKey(readKey()))
+	}
+	if schemaPath != "" {
+		opts = append(opts, config.WithSchema(readSchema(schemaPath)))
+	}
+	return opts
+}
+
+// readInput lê o arquivo informado, ou a entrada padrão quando o caminho é -.
+func readInput(path string) ([]byte, error) {
+	if path == config.StdinPath {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// exitInvalidConfig é o código de saída usado quando a validação encontra erros,
+// permitindo que pipelines diferenciem configuração inválida de falhas de leitura.
+const exitInvalidConfig = 2
+
+func exitLoadError(err error) {
+	var errs config.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e.Error())
 		}
+		fmt.Fprintln(os.Stderr, "Configuração inválida.")
+		os.Exit(exitInvalidConfig)
+	}
+	if errors.Is(err, config.ErrUnsigned) || errors.Is(err, config.ErrInvalidSignature) {
+		exitSignatureError(err)
 	}
+	fmt.Fprintln(os.Stderr, err)
+	fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+	os.Exit(1)
 }
 
 func AsyncResponseTime(wg *sync.WaitGroup, webservers <-chan config.WebsiteConfig, id int) {
@@ -208,8 +319,7 @@ func AsyncHealthCheck(wg *sync.WaitGroup, servers <-chan config.ServerConfig, id
 	for server := range servers {
 		if server.Host != "" {
 
-			url := fmt.Sprintf("%s://%s:%d/%s", server.Protocol, server.Host, server.Port, server.Healthcheck)
-			req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+			req, err := http.NewRequestWithContext(ctx, "GET", server.HealthURL(), nil)
 
 			if err != nil {
 				fmt.Printf("Erro ao acessar o servidor Worker %d (%s): %v\n", id, server.Name, err)
@@ -247,10 +357,23 @@ func init() {
 	rootCmd.AddCommand(serverCmd)
 	rootCmd.AddCommand(testHealthStatus)
 	rootCmd.AddCommand(responseCheck)
-	parseCmd.Flags().StringVarP(&filePath, "file", "f", "", "Arquivo de configuração (YAML ou JSON)")
-	serverCmd.Flags().StringVarP(&filePath, "file", "f", "", "Arquivo de configuração (YAML ou JSON)")
-	testHealthStatus.Flags().StringVarP(&filePath, "file", "f", "", "Arquivo de configuração (YAML ou JSON)")
-	responseCheck.Flags().StringVarP(&filePath, "file", "f", "", "Arquivo de configuração (YAML ou JSON)")
+	parseCmd.Flags().StringVar(&schemaPath, "schema", "", "Valida o arquivo contra um JSON Schema")
+	parseCmd.Flags().BoolVar(&watchFile, "watch", false, "Continua executando e recarrega o arquivo a cada alteração")
+	parseCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "Intervalo entre as verificações do --watch")
+	parseCmd.Flags().BoolVar(&showResolved, "resolved", false, "Exibe o valor efetivo de cada campo dos servidores e websites e de onde ele veio (entrada ou defaults)")
+	parseCmd.Flags().BoolVar(&splitDocuments, "split", false, "Processa cada documento YAML (---) separadamente em vez de mesclá-los")
+	for _, c := range []*cobra.Command{parseCmd, serverCmd} {
+		c.Flags().StringVarP(&outputFormat, "output", "o", "table", "Formato de saída: table, wide, json ou yaml")
+	}
+	for _, c := range []*cobra.Command{parseCmd, serverCmd, testHealthStatus, responseCheck} {
+		c.Flags().StringArrayVarP(&filePaths, "file", "f", nil, "Arquivo de configuração (YAML ou JSON, - para stdin); repita para aplicar overlays em ordem")
+		c.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+		c.Flags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	}
+	for _, c := range []*cobra.Command{serverCmd, testHealthStatus, responseCheck} {
+		c.Flags().StringVarP(&selectorExpr, "selector", "l", "", "Filtra por labels e tags, ex.: env=prod,tier!=db,web")
+		c.Flags().StringSliceVar(&nameFilters, "name", nil, "Filtra pelo nome, aceita padrões como 'httpbin-*'")
+	}
 	parseCmd.MarkFlagRequired("file")
 	serverCmd.MarkFlagRequired("file")
 	testHealthStatus.MarkFlagRequired("file")
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var renderOutput string
+var renderImage string
+var renderNamespace string
+var renderDatabaseImage string
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Gera arquivos de implantação a partir da configuração",
+}
+
+var renderK8sCmd = &cobra.Command{
+	Use:   "k8s",
+	Short: "Gera Deployments, Services e o Secret do banco de dados para Kubernetes",
+	Run: func(cmd *cobra.Command, args []string) {
+		// Sem replicas no arquivo o Deployment tem uma réplica; replicas: 0 é mantido.
+		cfg := loadConfig(filePaths, config.WithDefaults(config.Defaults{Server: config.ServerConfig{Replicas: 1}}))
+		manifests, err := config.RenderKubernetes(cfg, config.KubernetesOptions{Image: renderImage, Namespace: renderNamespace})
+		if err != nil {
+			fmt.Println("Erro ao gerar os manifests:", err)
+			os.Exit(1)
+		}
+
+		if renderOutput != "" {
+			if err := config.WriteKustomize(renderOutput, manifests, renderNamespace); err != nil {
+				fmt.Println("Erro ao escrever os manifests:", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "%d manifest(s) gravado(s) em %s\n", len(manifests), renderOutput)
+			return
+		}
+		out, err := config.EncodeManifests(manifests)
+		if err != nil {
+			fmt.Println("Erro ao gerar os manifests:", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(out)
+	},
+}
+
+var renderComposeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Gera um docker-compose.yml com um serviço por servidor e o banco de dados",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfig(filePaths)
+		out, err := config.RenderCompose(cfg, config.ComposeOptions{Image: renderImage, DatabaseImage: renderDatabaseImage})
+		if err != nil {
+			fmt.Println("Erro ao gerar o docker-compose.yml:", err)
+			os.Exit(1)
+		}
+
+		if renderOutput == "" {
+			os.Stdout.Write(out)
+			return
+		}
+		if err := os.WriteFile(renderOutput, out, 0644); err != nil {
+			fmt.Println("Erro ao escrever o docker-compose.yml:", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+	renderCmd.AddCommand(renderK8sCmd, renderComposeCmd)
+	renderCmd.PersistentFlags().StringArrayVarP(&filePaths, "file", "f", nil, "Arquivo de configuração (YAML ou JSON, - para stdin); repita para aplicar overlays em ordem")
+	renderCmd.PersistentFlags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+	renderCmd.PersistentFlags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	renderCmd.MarkPersistentFlagRequired("file")
+	renderK8sCmd.Flags().StringVarP(&renderOutput, "output", "o", "", "Diretório kustomize de saída (padrão: YAML com vários documentos na saída padrão)")
+	renderCmd.PersistentFlags().StringVar(&renderImage, "image", "{name}:latest", "Imagem dos containers; {name} é trocado pelo nome do servidor")
+	renderK8sCmd.Flags().StringVarP(&renderNamespace, "namespace", "n", "", "Namespace dos recursos")
+	renderComposeCmd.Flags().StringVarP(&renderOutput, "output", "o", "", "Arquivo de saída (padrão: saída padrão)")
+	renderComposeCmd.Flags().StringVar(&renderDatabaseImage, "database-image", "postgres:16", "Imagem do serviço do banco de dados; postgres, mysql e mariadb definem a porta interna e o usuário")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Gera o JSON Schema (draft 2020-12) do arquivo de configuração",
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := json.MarshalIndent(config.GenerateSchema(), "", "  ")
+		if err != nil {
+			fmt.Println("Erro ao gerar o schema:", err)
+			os.Exit(1)
+		}
+		data = append(data, '\n')
+
+		if schemaOutput == "" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(schemaOutput, data, 0644); err != nil {
+			fmt.Println("Erro ao escrever o schema:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Schema gravado em %s\n", schemaOutput)
+	},
+}
+
+// readSchema lê um JSON Schema externo usado por parse --schema.
+func readSchema(path string) *config.Schema {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Println("Erro ao ler o schema:", err)
+		os.Exit(1)
+	}
+	schema, err := config.ParseSchema(raw)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return schema
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "Arquivo de saída (padrão: stdout)")
+}
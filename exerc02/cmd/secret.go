@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const keyFileEnv = "CONFIGPARSER_KEY_FILE"
+
+var keyFile string
+var secretFile string
+var secretInPlace bool
+var generateKey bool
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Cifra os campos secretos do arquivo de configuração",
+	Run: func(cmd *cobra.Command, args []string) {
+		if generateKey {
+			if _, err := os.Stat(keyFile); err == nil {
+				fmt.Printf("A chave %s já existe\n", keyFile)
+				os.Exit(1)
+			}
+			key, err := config.GenerateKey()
+			if err == nil {
+				err = os.WriteFile(keyFile, config.EncodeKey(key), 0600)
+			}
+			if err != nil {
+				fmt.Println("Erro ao gerar a chave:", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Chave gerada em %s\n", keyFile)
+		}
+
+		root, format := readSecretFile()
+		count, err := config.EncryptNode(root, readKey())
+		if err != nil {
+			fmt.Println("Erro ao cifrar:", err)
+			os.Exit(1)
+		}
+		writeSecretFile(root, format)
+		fmt.Fprintf(os.Stderr, "%d valor(es) cifrado(s)\n", count)
+	},
+}
+
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decifra os valores ENC[...] do arquivo de configuração",
+	Run: func(cmd *cobra.Command, args []string) {
+		root, format := readSecretFile()
+		count, err := config.DecryptNode(secretFile, root, readKey())
+		if err != nil {
+			fmt.Println("Erro ao decifrar:", err)
+			os.Exit(1)
+		}
+		writeSecretFile(root, format)
+		fmt.Fprintf(os.Stderr, "%d valor(es) decifrado(s)\n", count)
+	},
+}
+
+func readKey() []byte {
+	if keyFile == "" {
+		fmt.Printf("Informe --key-file ou $%s\n", keyFileEnv)
+		os.Exit(1)
+	}
+	key, err := config.LoadKey(keyFile)
+	if err != nil {
+		fmt.Println("Erro ao ler a chave:", err)
+		os.Exit(1)
+	}
+	return key
+}
+
+func readSecretFile() (*yaml.Node, config.Format) {
+	if secretInPlace && secretFile == config.StdinPath {
+		fmt.Println("--in-place não pode ser usado com a entrada padrão")
+		os.Exit(1)
+	}
+	data, err := readInput(secretFile)
+	if err != nil {
+		fmt.Println("Erro ao ler o arquivo:", err)
+		os.Exit(1)
+	}
+	format := config.DetectFormat(secretFile, data)
+	root, err := config.ParseNodeAs(secretFile, data, format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+		os.Exit(1)
+	}
+	return root, format
+}
+
+func writeSecretFile(root *yaml.Node, format config.Format) {
+	out, err := config.Encode(root, format)
+	if err != nil {
+		fmt.Println("Erro ao gerar o arquivo:", err)
+		os.Exit(1)
+	}
+	if !secretInPlace {
+		os.Stdout.Write(out)
+		return
+	}
+	info, err := os.Stat(secretFile)
+	if err == nil {
+		err = os.WriteFile(secretFile, out, info.Mode().Perm())
+	}
+	if err != nil {
+		fmt.Println("Erro ao escrever o arquivo:", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(encryptCmd)
+	rootCmd.AddCommand(decryptCmd)
+	for _, c := range []*cobra.Command{encryptCmd, decryptCmd} {
+		c.Flags().StringVarP(&secretFile, "file", "f", "", "Arquivo de configuração")
+		c.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Arquivo com a chave AES-256 (ou $"+keyFileEnv+")")
+		c.Flags().BoolVarP(&secretInPlace, "in-place", "i", false, "Grava o resultado no próprio arquivo")
+		c.MarkFlagRequired("file")
+	}
+	encryptCmd.Flags().BoolVar(&generateKey, "generate-key", false, "Gera uma nova chave em --key-file antes de cifrar")
+}
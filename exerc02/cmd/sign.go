@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+const signingKeyEnv = "CONFIGPARSER_SIGNING_KEY"
+const publicKeyEnv = "CONFIGPARSER_PUBLIC_KEY"
+
+var signFile string
+var signingKeyFile string
+var publicKeyFile string
+var generateSigningKey bool
+var requireSignature bool
+
+var signCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Assina o arquivo de configuração com uma chave ed25519 (assinatura em <arquivo>.sig)",
+	Run: func(cmd *cobra.Command, args []string) {
+		if signFile == config.StdinPath {
+			fmt.Println("A assinatura destacada precisa de um arquivo; a entrada padrão não é suportada")
+			os.Exit(1)
+		}
+		if signingKeyFile == "" {
+			fmt.Printf("Informe --key ou $%s\n", signingKeyEnv)
+			os.Exit(1)
+		}
+		if generateSigningKey {
+			writeSigningKeys()
+		}
+
+		key, err := config.LoadPrivateKey(signingKeyFile)
+		if err != nil {
+			fmt.Println("Erro ao ler a chave:", err)
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(signFile)
+		if err != nil {
+			fmt.Println("Erro ao ler o arquivo:", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(config.SignatureFile(signFile), config.Sign(data, key), 0644); err != nil {
+			fmt.Println("Erro ao gravar a assinatura:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Assinatura gravada em %s\n", config.SignatureFile(signFile))
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Confere a assinatura ed25519 do arquivo de configuração",
+	Run: func(cmd *cobra.Command, args []string) {
+		verifySignatures([]string{signFile})
+		fmt.Printf("%s: assinatura válida\n", signFile)
+	},
+}
+
+// writeSigningKeys gera um par de chaves em --key e --key.pub, sem sobrescrever
+// chaves existentes.
+func writeSigningKeys() {
+	publicPath := signingKeyFile + ".pub"
+	for _, p := range []string{signingKeyFile, publicPath} {
+		if _, err := os.Stat(p); err == nil {
+			fmt.Printf("A chave %s já existe\n", p)
+			os.Exit(1)
+		}
+	}
+	pub, priv, err := config.GenerateSigningKey()
+	var privPEM, pubPEM []byte
+	if err == nil {
+		privPEM, err = config.EncodePrivateKey(priv)
+	}
+	if err == nil {
+		pubPEM, err = config.EncodePublicKey(pub)
+	}
+	if err == nil {
+		err = os.WriteFile(signingKeyFile, privPEM, 0600)
+	}
+	if err == nil {
+		err = os.WriteFile(publicPath, pubPEM, 0644)
+	}
+	if err != nil {
+		fmt.Println("Erro ao gerar a chave:", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Chaves geradas em %s e %s\n", signingKeyFile, publicPath)
+}
+
+// verifySignatures confere a assinatura de cada arquivo com --public-key,
+// encerrando o processo se algum não estiver assinado ou tiver sido alterado.
+func verifySignatures(paths []string) {
+	key := readPublicKey()
+	for _, p := range paths {
+		if p == config.StdinPath {
+			fmt.Println("A entrada padrão não pode ter a assinatura conferida")
+			os.Exit(1)
+		}
+		if err := config.VerifyFile(p, key); err != nil {
+			exitSignatureError(err)
+		}
+	}
+}
+
+// readPublicKey lê a chave de --public-key, encerrando o processo em caso de erro.
+func readPublicKey() ed25519.PublicKey {
+	if publicKeyFile == "" {
+		fmt.Printf("Informe --public-key ou $%s\n", publicKeyEnv)
+		os.Exit(1)
+	}
+	key, err := config.LoadPublicKey(publicKeyFile)
+	if err != nil {
+		fmt.Println("Erro ao ler a chave pública:", err)
+		os.Exit(1)
+	}
+	return key
+}
+
+func exitSignatureError(err error) {
+	fmt.Println(err)
+	if errors.Is(err, config.ErrUnsigned) {
+		fmt.Println("Assine o arquivo com: sign --file <arquivo> --key <chave>")
+	}
+	os.Exit(1)
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd)
+	rootCmd.AddCommand(verifyCmd)
+	for _, c := range []*cobra.Command{signCmd, verifyCmd} {
+		c.Flags().StringVarP(&signFile, "file", "f", "", "Arquivo de configuração")
+		c.MarkFlagRequired("file")
+	}
+	signCmd.Flags().StringVar(&signingKeyFile, "key", os.Getenv(signingKeyEnv), "Chave privada ed25519 em PEM (ou $"+signingKeyEnv+")")
+	signCmd.Flags().BoolVar(&generateSigningKey, "generate-key", false, "Gera um novo par de chaves em --key e --key.pub antes de assinar")
+	verifyCmd.Flags().StringVar(&publicKeyFile, "public-key", os.Getenv(publicKeyEnv), "Chave pública ed25519 em PEM (ou $"+publicKeyEnv+")")
+	for _, c := range []*cobra.Command{testHealthStatus, responseCheck} {
+		c.Flags().BoolVar(&requireSignature, "require-signature", false, "Recusa arquivos sem assinatura válida (veja sign/verify)")
+		c.Flags().StringVar(&publicKeyFile, "public-key", os.Getenv(publicKeyEnv), "Chave pública ed25519 em PEM (ou $"+publicKeyEnv+")")
+	}
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"configparser-exerc02/config"
+
+	"github.com/spf13/cobra"
+)
+
+var validateOutput string
+var validateResolve bool
+var validateDial bool
+var validateTimeout time.Duration
+
+// validateReport é a saída em JSON/YAML do validate.
+type validateReport struct {
+	Problems     config.ValidationErrors `json:"problems" yaml:"problems"`
+	Reachability []config.Reachability   `json:"reachability,omitempty" yaml:"reachability,omitempty"`
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Valida a configuração e, com --resolve, se os hosts resolvem e as portas respondem",
+	Run: func(cmd *cobra.Command, args []string) {
+		if validateOutput != "table" && validateOutput != "json" && validateOutput != "yaml" {
+			fmt.Printf("formato de saída desconhecido %q (use table, json ou yaml)\n", validateOutput)
+			os.Exit(1)
+		}
+
+		filter, err := config.NewFilter(selectorExpr, nameFilters)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		// A validação roda sobre o arquivo inteiro para que os caminhos (servers[i])
+		// apontem para as entradas do arquivo; o filtro só escolhe o que é exibido.
+		cfg := decodeConfig(filePaths)
+		var report validateReport
+		for _, e := range config.Validate(cfg) {
+			if filter.Selects(cfg, e.Path) {
+				report.Problems = append(report.Problems, e)
+			}
+		}
+		failed := report.Problems.HasErrors()
+
+		if validateResolve || validateDial {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			report.Reachability = config.CheckReachability(ctx, filter.Apply(cfg), config.ReachabilityOptions{
+				Dial:    validateDial,
+				Timeout: validateTimeout,
+			})
+			// Os servidores verificados são só os filtrados, na ordem do arquivo;
+			// os caminhos voltam a usar o índice do arquivo.
+			var selected []int
+			for i := range cfg.Servers {
+				if filter.Selects(cfg, fmt.Sprintf("servers[%d]", i)) {
+					selected = append(selected, i)
+				}
+			}
+			for j, i := range selected {
+				report.Reachability[j].Path = fmt.Sprintf("servers[%d]", i)
+			}
+			for _, r := range report.Reachability {
+				failed = failed || !r.OK()
+			}
+		}
+
+		if report.Problems == nil {
+			report.Problems = config.ValidationErrors{}
+		}
+		if err := renderValidate(os.Stdout, report, validateOutput); err != nil {
+			fmt.Println("Erro ao gerar a saída:", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(exitInvalidConfig)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().StringArrayVarP(&filePaths, "file", "f", nil, "Arquivo de configuração (YAML ou JSON, - para stdin); repita para aplicar overlays em ordem")
+	validateCmd.MarkFlagRequired("file")
+	validateCmd.Flags().StringVar(&keyFile, "key-file", os.Getenv(keyFileEnv), "Chave para decifrar valores ENC[...] (ou $"+keyFileEnv+")")
+	validateCmd.Flags().BoolVar(&allowMissingEnv, "allow-missing-env", false, "Trata variáveis de ambiente ausentes como aviso")
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", "table", "Formato de saída: table, json ou yaml")
+	validateCmd.Flags().BoolVar(&validateResolve, "resolve", false, "Resolve no DNS o host de cada servidor e do banco de dados e confere as portas")
+	validateCmd.Flags().BoolVar(&validateDial, "dial", false, "Abre uma conexão TCP com cada host (implica --resolve)")
+	validateCmd.Flags().DurationVar(&validateTimeout, "timeout", 5*time.Second, "Tempo limite de cada consulta DNS e conexão")
+	validateCmd.Flags().StringVarP(&selectorExpr, "selector", "l", "", "Filtra por labels e tags, ex.: env=prod,tier!=db,web")
+	validateCmd.Flags().StringSliceVar(&nameFilters, "name", nil, "Filtra pelo nome, aceita padrões como 'httpbin-*'")
+}
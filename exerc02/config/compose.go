@@ -0,0 +1,399 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Labels usados para guardar, no docker-compose.yml, os campos que não têm
+// equivalente no Compose e permitir a volta com ImportCompose.
+const (
+	ComposeLabelName     = "configparser.name"
+	ComposeLabelHost     = "configparser.host"
+	ComposeLabelProtocol = "configparser.protocol"
+	ComposeLabelTags     = "configparser.tags"
+	ComposeLabelRole     = "configparser.role"
+	// ComposeLabelPrefix antecede os labels do próprio servidor.
+	ComposeLabelPrefix = "configparser.label."
+
+	composeDatabaseService = "database"
+	composeExtension       = "x-configparser"
+)
+
+// ComposeOptions controla a geração do docker-compose.yml.
+type ComposeOptions struct {
+	// Image é a imagem dos servidores; {name} é trocado pelo nome do serviço.
+	Image         string
+	DatabaseImage string
+}
+
+type composeService struct {
+	Image       string              `yaml:"image,omitempty"`
+	Deploy      *composeDeploy      `yaml:"deploy,omitempty"`
+	Ports       []string            `yaml:"ports,omitempty"`
+	Environment map[string]string   `yaml:"environment,omitempty"`
+	Healthcheck *composeHealthcheck `yaml:"healthcheck,omitempty"`
+	Labels      map[string]string   `yaml:"labels,omitempty"`
+}
+
+type composeDeploy struct {
+	Replicas int `yaml:"replicas"`
+}
+
+type composeHealthcheck struct {
+	Test     []string `yaml:"test"`
+	Interval string   `yaml:"interval,omitempty"`
+}
+
+// composeDatabase descreve, para uma imagem de banco de dados conhecida, a porta
+// dentro do container e as variáveis que criam o usuário.
+type composeDatabase struct {
+	port        int
+	userEnv     string
+	passwordEnv string
+	env         map[string]string
+}
+
+var composeDatabases = map[string]composeDatabase{
+	"postgres": {port: 5432, userEnv: "POSTGRES_USER", passwordEnv: "POSTGRES_PASSWORD"},
+	"mysql":    {port: 3306, userEnv: "MYSQL_USER", passwordEnv: "MYSQL_PASSWORD", env: map[string]string{"MYSQL_RANDOM_ROOT_PASSWORD": "yes"}},
+	"mariadb":  {port: 3306, userEnv: "MARIADB_USER", passwordEnv: "MARIADB_PASSWORD", env: map[string]string{"MARIADB_RANDOM_ROOT_PASSWORD": "yes"}},
+}
+
+// databaseService gera o serviço do banco de dados. Em imagens desconhecidas a
+// porta do container é a mesma de db.Port e usuário e senha não são repassados.
+func databaseService(db DatabaseConfig, image string) composeService {
+	service := composeService{
+		Image:  image,
+		Ports:  []string{fmt.Sprintf("%d:%d", db.Port, db.Port)},
+		Labels: map[string]string{ComposeLabelRole: "database", ComposeLabelHost: db.Host},
+	}
+	known, ok := composeDatabases[imageName(image)]
+	if !ok {
+		return service
+	}
+	service.Ports = []string{fmt.Sprintf("%d:%d", db.Port, known.port)}
+	service.Environment = map[string]string{known.userEnv: db.User, known.passwordEnv: db.Password}
+	for k, v := range known.env {
+		service.Environment[k] = v
+	}
+	return service
+}
+
+// imageName retorna o nome da imagem sem registro, tag e digest.
+func imageName(image string) string {
+	image = image[strings.LastIndex(image, "/")+1:]
+	if i := strings.IndexAny(image, ":@"); i >= 0 {
+		image = image[:i]
+	}
+	return image
+}
+
+// composeExtra guarda o que não vira serviço, como os websites.
+type composeExtra struct {
+	Websites []WebsiteConfig `yaml:"websites,omitempty"`
+}
+
+// RenderCompose gera um docker-compose.yml com um serviço por servidor, escalado
+// por Replicas e com healthcheck a partir de Healthcheck e Protocol, e um serviço
+// para o banco de dados. Os websites vão na extensão x-configparser.
+func RenderCompose(cfg Config, opts ComposeOptions) ([]byte, error) {
+	if opts.Image == "" {
+		opts.Image = "{name}:latest"
+	}
+	if opts.DatabaseImage == "" {
+		opts.DatabaseImage = "postgres:16"
+	}
+
+	services := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(name string, s composeService) error {
+		var n yaml.Node
+		if err := n.Encode(s); err != nil {
+			return err
+		}
+		services.Content = append(services.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, &n)
+		return nil
+	}
+
+	seen := map[string]string{composeDatabaseService: "database"}
+	for _, s := range cfg.Servers {
+		name := DNSLabel(s.Name)
+		if name == "" {
+			return nil, fmt.Errorf("servidor %q não gera um nome de serviço válido", s.Name)
+		}
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("servidores %q e %q geram o mesmo serviço %q", other, s.Name, name)
+		}
+		seen[name] = s.Name
+
+		service := composeService{
+			Image:  strings.ReplaceAll(opts.Image, "{name}", name),
+			Labels: map[string]string{ComposeLabelName: s.Name, ComposeLabelHost: s.Host},
+		}
+		if s.Protocol != "" {
+			service.Labels[ComposeLabelProtocol] = s.Protocol
+		}
+		if len(s.Tags) > 0 {
+			service.Labels[ComposeLabelTags] = strings.Join(s.Tags, ",")
+		}
+		for k, v := range s.Labels {
+			service.Labels[ComposeLabelPrefix+k] = v
+		}
+		if s.Replicas > 0 {
+			service.Deploy = &composeDeploy{Replicas: s.Replicas}
+		}
+		if s.Replicas > 1 {
+			// Várias réplicas não podem publicar a mesma porta no host.
+			service.Ports = []string{strconv.Itoa(s.Port)}
+		} else {
+			service.Ports = []string{fmt.Sprintf("%d:%d", s.Port, s.Port)}
+		}
+		if s.Healthcheck != "" {
+			url := fmt.Sprintf("%s://localhost:%d%s", portName(s.Protocol), s.Port, healthcheckPath(s.Healthcheck))
+			interval := s.Interval
+			if interval == "" {
+				interval = composeHealthInterval
+			}
+			service.Healthcheck = &composeHealthcheck{Test: []string{"CMD", "curl", "-fsk", url}, Interval: interval}
+		}
+		if err := add(name, service); err != nil {
+			return nil, err
+		}
+	}
+
+	if db := cfg.Database; db != (DatabaseConfig{}) {
+		if err := add(composeDatabaseService, databaseService(db, opts.DatabaseImage)); err != nil {
+			return nil, err
+		}
+	}
+
+	file := struct {
+		Services *yaml.Node    `yaml:"services"`
+		Extra    *composeExtra `yaml:"x-configparser,omitempty"`
+	}{Services: services}
+	if len(cfg.Website) > 0 {
+		file.Extra = &composeExtra{Websites: cfg.Website}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// composeHealthInterval é o intervalo do healthcheck dos servidores sem interval.
+const composeHealthInterval = "10s"
+
+func healthcheckPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path
+	}
+	return path
+}
+
+var composeHealthURL = regexp.MustCompile(`(https?)://[^/\s:]+(?::\d+)?(/[^\s"']*)?`)
+
+// ImportCompose lê um docker-compose.yml e monta a configuração equivalente. O
+// serviço com o label configparser.role=database (ou chamado database) vira o
+// banco de dados; os demais viram servidores. Serviços sem porta não podem ser
+// convertidos e são retornados como ValidationErrors.
+func ImportCompose(filename string, data []byte) (Config, error) {
+	filename = displayName(filename)
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return Config{}, yamlDecodeError(filename, err)
+	}
+	doc := unwrapDocument(&root)
+	if doc == nil || doc.Kind != yaml.MappingNode {
+		return Config{}, errors.New(filename + ": o docker-compose.yml deve ser um objeto")
+	}
+	services := mappingValue(doc, "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return Config{}, errors.New(filename + ": campo services ausente")
+	}
+
+	cfg := Config{APIVersion: APIVersionV2, Kind: KindConfig}
+	var errs ValidationErrors
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name, n := services.Content[i].Value, resolveAlias(services.Content[i+1])
+		path := "services." + name
+		labels := composeMap(mappingValue(n, "labels"))
+		ports := composePorts(mappingValue(n, "ports"))
+		if len(ports) == 0 {
+			ports = composePorts(mappingValue(n, "expose"))
+		}
+
+		if labels[ComposeLabelRole] == "database" || (name == composeDatabaseService && labels[ComposeLabelRole] == "") {
+			env := composeMap(mappingValue(n, "environment"))
+			db := DatabaseConfig{Host: labels[ComposeLabelHost], User: firstOf(env, "POSTGRES_USER", "MYSQL_USER", "MARIADB_USER"), Password: firstOf(env, "POSTGRES_PASSWORD", "MYSQL_PASSWORD", "MARIADB_PASSWORD")}
+			if db.Host == "" {
+				db.Host = name
+			}
+			if len(ports) > 0 {
+				db.Port = ports[0].published
+			}
+			if db.Port == 0 {
+				errs.add(path+".ports", "compose", "o banco de dados não publica nenhuma porta", SeverityError)
+			}
+			cfg.Database = db
+			continue
+		}
+
+		if len(ports) == 0 {
+			errs.add(path+".ports", "compose", "o serviço não declara nenhuma porta", SeverityError)
+			continue
+		}
+		server := ServerConfig{Name: labels[ComposeLabelName], Host: labels[ComposeLabelHost], Port: ports[0].target}
+		if server.Name == "" {
+			// Serviço que não foi gerado por RenderCompose.
+			server.Name, server.Protocol = name, "http"
+		}
+		if server.Host == "" {
+			server.Host = name
+		}
+		if deploy := mappingValue(n, "deploy"); deploy != nil {
+			if replicas := mappingValue(deploy, "replicas"); replicas != nil {
+				server.Replicas, _ = strconv.Atoi(replicas.Value)
+			}
+		}
+		if hc := mappingValue(n, "healthcheck"); hc != nil {
+			if m := composeHealthURL.FindStringSubmatch(composeCommand(mappingValue(hc, "test"))); m != nil {
+				server.Protocol, server.Healthcheck = m[1], m[2]
+				if server.Healthcheck == "" {
+					server.Healthcheck = "/"
+				}
+			}
+			// O intervalo padrão é o que RenderCompose usa para servidores sem interval.
+			if interval := mappingValue(hc, "interval"); interval != nil && interval.Value != composeHealthInterval {
+				server.Interval = interval.Value
+			}
+		}
+		if protocol := labels[ComposeLabelProtocol]; protocol != "" {
+			server.Protocol = protocol
+		}
+		if tags := labels[ComposeLabelTags]; tags != "" {
+			server.Tags = strings.Split(tags, ",")
+		}
+		for k, v := range labels {
+			if key, ok := strings.CutPrefix(k, ComposeLabelPrefix); ok {
+				if server.Labels == nil {
+					server.Labels = map[string]string{}
+				}
+				server.Labels[key] = v
+			}
+		}
+		cfg.Servers = append(cfg.Servers, server)
+	}
+
+	if extra := mappingValue(doc, composeExtension); extra != nil {
+		var x composeExtra
+		if err := extra.Decode(&x); err != nil {
+			return Config{}, fmt.Errorf("%s: %s: %w", filename, composeExtension, err)
+		}
+		cfg.Website = x.Websites
+	}
+
+	if errs.HasErrors() {
+		return cfg, errs
+	}
+	return cfg, nil
+}
+
+func firstOf(m map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// composeMap lê environment e labels, que o Compose aceita como objeto ou como
+// lista de CHAVE=valor.
+func composeMap(n *yaml.Node) map[string]string {
+	m := map[string]string{}
+	if n == nil {
+		return m
+	}
+	switch n = resolveAlias(n); {
+	case n.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			m[n.Content[i].Value] = n.Content[i+1].Value
+		}
+	case n.Kind == yaml.SequenceNode:
+		for _, item := range n.Content {
+			k, v, _ := strings.Cut(item.Value, "=")
+			m[k] = v
+		}
+	}
+	return m
+}
+
+type composePort struct {
+	published int
+	target    int
+}
+
+// composePorts lê portas na forma curta ("8080", "80:8080", "127.0.0.1:80:8080/tcp")
+// ou longa (target/published).
+func composePorts(n *yaml.Node) []composePort {
+	if n == nil || resolveAlias(n).Kind != yaml.SequenceNode {
+		return nil
+	}
+	var ports []composePort
+	for _, item := range resolveAlias(n).Content {
+		var p composePort
+		if item.Kind == yaml.MappingNode {
+			p.target, _ = strconv.Atoi(scalarValue(mappingValue(item, "target")))
+			p.published, _ = strconv.Atoi(scalarValue(mappingValue(item, "published")))
+		} else {
+			spec, _, _ := strings.Cut(item.Value, "/")
+			parts := strings.Split(spec, ":")
+			p.target, _ = strconv.Atoi(parts[len(parts)-1])
+			if len(parts) > 1 {
+				p.published, _ = strconv.Atoi(parts[len(parts)-2])
+			}
+		}
+		if p.published == 0 {
+			p.published = p.target
+		}
+		if p.target > 0 {
+			ports = append(ports, p)
+		}
+	}
+	return ports
+}
+
+func scalarValue(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	return n.Value
+}
+
+// composeCommand junta o test do healthcheck, que pode ser texto ou lista.
+func composeCommand(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	if n = resolveAlias(n); n.Kind != yaml.SequenceNode {
+		return n.Value
+	}
+	parts := make([]string, len(n.Content))
+	for i, item := range n.Content {
+		parts[i] = item.Value
+	}
+	return strings.Join(parts, " ")
+}
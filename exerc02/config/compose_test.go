@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComposeRoundTrip(t *testing.T) {
+	cfg := Config{
+		APIVersion: APIVersionV2,
+		Kind:       KindConfig,
+		Servers: []ServerConfig{
+			{Name: "Web App", Host: "web.internal", Port: 443, Replicas: 3, Healthcheck: "/status/200", Protocol: "https"},
+			{Name: "worker", Host: "localhost", Port: 9000, Replicas: 1, Protocol: "http", Tags: []string{"jobs", "prod"}, Labels: map[string]string{"tier": "backend"}},
+			{Name: "cache", Host: "localhost", Port: 6379},
+			{Name: "api", Host: "localhost", Port: 8080, Healthcheck: "/health", Protocol: "http", Interval: "1m"},
+		},
+		Database: DatabaseConfig{Host: "localhost", Port: 5433, User: "admin", Password: "secret"},
+		Website:  []WebsiteConfig{{Name: "GitHub", Url: "https://github.com", MaxResponseTime: 2000}},
+	}
+
+	data, err := RenderCompose(cfg, ComposeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"web-app:", "replicas: 3", "- \"443\"", "https://localhost:443/status/200", "- 5433:5432", "POSTGRES_PASSWORD: secret", "interval: 10s", "interval: 1m"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("docker-compose.yml sem %q:\n%s", expected, data)
+		}
+	}
+
+	imported, err := ImportCompose("docker-compose.yml", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported, cfg) {
+		t.Errorf("A configuração importada deve ser igual à original:\n%+v\n%+v", imported, cfg)
+	}
+}
+
+func TestRenderComposeDatabaseImage(t *testing.T) {
+	cfg := Config{Database: DatabaseConfig{Host: "localhost", Port: 13306, User: "app", Password: "pw"}}
+	cases := map[string][]string{
+		"mysql:8":                            {"- 13306:3306", "MYSQL_USER: app", "MYSQL_PASSWORD: pw", "MYSQL_RANDOM_ROOT_PASSWORD"},
+		"registry.local/postgres@sha256:abc": {"- 13306:5432", "POSTGRES_USER: app"},
+		"redis:7":                            {"- 13306:13306"},
+	}
+	for image, expected := range cases {
+		data, err := RenderCompose(cfg, ComposeOptions{DatabaseImage: image})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range expected {
+			if !strings.Contains(string(data), e) {
+				t.Errorf("%s: docker-compose.yml sem %q:\n%s", image, e, data)
+			}
+		}
+		if image == "redis:7" && strings.Contains(string(data), "environment") {
+			t.Errorf("Imagem desconhecida não deveria receber variáveis:\n%s", data)
+		}
+		if image == "mysql:8" {
+			imported, err := ImportCompose("docker-compose.yml", data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported.Database != cfg.Database {
+				t.Errorf("Banco de dados importado inesperado: %+v", imported.Database)
+			}
+		}
+	}
+}
+
+func TestImportComposeForeignFile(t *testing.T) {
+	data := []byte(`
+services:
+  api:
+    image: api:1.0
+    ports:
+      - "127.0.0.1:8081:8080/tcp"
+    environment:
+      - DEBUG=1
+    healthcheck:
+      test: curl -f http://localhost:8080/health || exit 1
+  db:
+    image: mysql:8
+    environment:
+      MYSQL_USER: app
+      MYSQL_PASSWORD: pw
+    labels:
+      - configparser.role=database
+    ports:
+      - target: 3306
+        published: 13306
+  worker:
+    image: worker:1.0
+`)
+	cfg, err := ImportCompose("docker-compose.yml", data)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "services.worker.ports" {
+		t.Fatalf("Esperado erro para o serviço sem porta, obtido %v", err)
+	}
+
+	expected := ServerConfig{Name: "api", Host: "api", Port: 8080, Healthcheck: "/health", Protocol: "http"}
+	if len(cfg.Servers) != 1 || !reflect.DeepEqual(cfg.Servers[0], expected) {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+	if cfg.Database != (DatabaseConfig{Host: "db", Port: 13306, User: "app", Password: "pw"}) {
+		t.Errorf("Banco de dados inesperado: %+v", cfg.Database)
+	}
+}
@@ -28,3 +28,10 @@ func TestValidConfig(t *testing.T) {
 		t.Error("Host do banco de dados deve estar vazio")
 	}
 }
+
+func TestServerConfigString(t *testing.T) {
+	s := ServerConfig{Name: "app-server", Host: "localhost", Port: 8080}
+	if got := s.String(); got != "app-server at localhost:8080" {
+		t.Errorf("String() inesperado: %s", got)
+	}
+}
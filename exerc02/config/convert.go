@@ -0,0 +1,135 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Encode serializa a árvore no formato pedido mantendo a ordem dos campos. Para
+// YAML, comentários presentes na árvore também são preservados.
+func Encode(n *yaml.Node, format Format) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(n); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatJSON:
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, unwrapDocument(n), ""); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	case FormatTOML:
+		return encodeTOML(n)
+	}
+	return nil, fmt.Errorf("formato desconhecido %q", format)
+}
+
+// NormalizeStyle remove estilos herdados de outro formato (objetos em linha e
+// aspas do JSON, por exemplo) para que a saída YAML use o estilo de bloco.
+func NormalizeStyle(n *yaml.Node) {
+	if n == nil {
+		return
+	}
+	if n.Kind == yaml.ScalarNode {
+		n.Tag = n.ShortTag()
+	}
+	n.Style = 0
+	for _, c := range n.Content {
+		NormalizeStyle(c)
+	}
+}
+
+func writeJSON(buf *bytes.Buffer, n *yaml.Node, indent string) error {
+	if n == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	n = resolveAlias(n)
+	inner := indent + "  "
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, _ := json.Marshal(n.Content[i].Value)
+			buf.WriteString(inner)
+			buf.Write(key)
+			buf.WriteString(": ")
+			if err := writeJSON(buf, n.Content[i+1], inner); err != nil {
+				return err
+			}
+			if i+2 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "}")
+		return nil
+	case yaml.SequenceNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, c := range n.Content {
+			buf.WriteString(inner)
+			if err := writeJSON(buf, c, inner); err != nil {
+				return err
+			}
+			if i+1 < len(n.Content) {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent + "]")
+		return nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatInt(i, 10))
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return fmt.Errorf("linha %d: JSON não representa o valor %s", n.Line, n.Value)
+		}
+		buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	default:
+		s, _ := json.Marshal(n.Value)
+		buf.Write(s)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const convertSample = `# inventário
+servers:
+  - name: app # principal
+    host: localhost
+    port: 8080
+    replicas: 2
+    protocol: http
+database:
+  host: localhost
+  port: 5432
+  user: admin
+  password: secret
+websites:
+  - name: Example
+    url: https://www.example.com
+    max_response_time: 2000
+`
+
+func TestEncodeYAMLPreservesComments(t *testing.T) {
+	root := parseTestNode(t, convertSample)
+	out, err := Encode(root, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != convertSample {
+		t.Errorf("YAML→YAML deveria preservar o documento:\n%s", out)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	expected, err := Decode("sample.yaml", []byte(convertSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := parseTestNode(t, convertSample)
+	for _, format := range []Format{FormatJSON, FormatTOML, FormatYAML} {
+		out, err := Encode(root, format)
+		if err != nil {
+			t.Fatalf("Encode(%s): %v", format, err)
+		}
+		if got := DetectFormat("", out); got != format {
+			t.Errorf("Saída %s detectada como %s", format, got)
+		}
+
+		root, err = ParseNodeAs("sample."+string(format), out, format)
+		if err != nil {
+			t.Fatalf("ParseNodeAs(%s): %v\n%s", format, err, out)
+		}
+		NormalizeStyle(root)
+
+		var cfg Config
+		if err := DecodeNode("sample", root, &cfg); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cfg, expected) {
+			t.Errorf("Round trip via %s alterou a configuração: %+v", format, cfg)
+		}
+	}
+}
+
+func TestEncodeTOMLKeepsOrder(t *testing.T) {
+	out, err := Encode(parseTestNode(t, convertSample), FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers := strings.Index(string(out), "[[servers]]")
+	database := strings.Index(string(out), "[database]")
+	websites := strings.Index(string(out), "[[websites]]")
+	if !(servers < database && database < websites) {
+		t.Errorf("Ordem das tabelas não preservada:\n%s", out)
+	}
+}
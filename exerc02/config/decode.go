@@ -0,0 +1,343 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+)
+
+// ParseFormat converte o nome informado pelo usuário em um Format conhecido.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatYAML, FormatJSON, FormatTOML:
+		return f, nil
+	case "yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("formato desconhecido %q (use yaml, json ou toml)", name)
+}
+
+var tomlLineRe = regexp.MustCompile(`^\s*(\[[^\]]+\]|[A-Za-z0-9_."-]+\s*=)`)
+
+// DetectFormat identifica o formato pela extensão do arquivo e, na falta dela,
+// pelo primeiro caractere significativo do conteúdo.
+func DetectFormat(filename string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	}
+	trimmed := bytes.TrimLeft(data, " \t\r\n\ufeff")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(data) {
+		return FormatJSON
+	}
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if tomlLineRe.MatchString(line) {
+			return FormatTOML
+		}
+		break
+	}
+	return FormatYAML
+}
+
+// DecodeError aponta a posição (arquivo:linha:coluna) de um problema no documento.
+type DecodeError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *DecodeError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+type DecodeErrors []*DecodeError
+
+func (e DecodeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Decode faz o parse estrito de um documento YAML ou JSON em Config, rejeitando
+// campos desconhecidos, chaves duplicadas e tipos incompatíveis.
+func Decode(filename string, data []byte) (Config, error) {
+	var cfg Config
+	root, err := ParseNode(filename, data)
+	if err != nil {
+		return cfg, err
+	}
+	if err := DecodeNode(filename, root, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+var yamlLineRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// ParseNode lê o documento como uma árvore yaml.Node, preservando posições e comentários.
+func ParseNode(filename string, data []byte) (*yaml.Node, error) {
+	return ParseNodeAs(filename, data, DetectFormat(filename, data))
+}
+
+// ParseNodeAs é como ParseNode, mas usa o formato informado em vez de detectá-lo.
+func ParseNodeAs(filename string, data []byte, format Format) (*yaml.Node, error) {
+	switch format {
+	case FormatTOML:
+		return parseTOML(filename, data)
+	case FormatJSON:
+		return parseJSON(filename, data)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlDecodeError(filename, err)
+	}
+	return &root, nil
+}
+
+// ParseDocuments é como ParseNode, mas retorna todos os documentos de um fluxo
+// YAML separado por ---. JSON e TOML têm sempre um único documento.
+func ParseDocuments(filename string, data []byte) ([]*yaml.Node, error) {
+	format := DetectFormat(filename, data)
+	if format != FormatYAML {
+		root, err := ParseNodeAs(filename, data, format)
+		if err != nil {
+			return nil, err
+		}
+		return []*yaml.Node{root}, nil
+	}
+
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var root yaml.Node
+		err := dec.Decode(&root)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, yamlDecodeError(filename, err)
+		}
+		if unwrapDocument(&root) != nil {
+			docs = append(docs, &root)
+		}
+	}
+}
+
+func yamlDecodeError(filename string, err error) *DecodeError {
+	if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &DecodeError{File: filename, Line: line, Message: m[2]}
+	}
+	return &DecodeError{File: filename, Line: 1, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+}
+
+func offsetToPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// DecodeNode decodifica uma árvore yaml.Node em out acumulando todos os erros encontrados.
+func DecodeNode(filename string, root *yaml.Node, out any) error {
+	return decodeNode(filename, root, out, true)
+}
+
+func decodeNode(filename string, root *yaml.Node, out any, strict bool) error {
+	d := &nodeDecoder{file: filename, strict: strict}
+	n := root
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		n = n.Content[0]
+	}
+	if n.Kind != yaml.MappingNode {
+		d.errorf(n, "o documento deve ser um objeto, encontrado %s", nodeKind(n))
+	} else {
+		d.decode(n, reflect.ValueOf(out).Elem(), "")
+	}
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+type nodeDecoder struct {
+	file   string
+	strict bool
+	errs   DecodeErrors
+}
+
+func (d *nodeDecoder) errorf(n *yaml.Node, format string, args ...any) {
+	d.errs = append(d.errs, &DecodeError{File: d.file, Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *nodeDecoder) decode(n *yaml.Node, v reflect.Value, path string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null" {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		d.decode(n, v.Elem(), path)
+	case reflect.Struct:
+		d.decodeStruct(n, v, path)
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			d.errorf(n, "%s: esperado lista, encontrado %s", path, nodeKind(n))
+			return
+		}
+		slice := reflect.MakeSlice(v.Type(), len(n.Content), len(n.Content))
+		for i, item := range n.Content {
+			d.decode(item, slice.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+		v.Set(slice)
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			d.errorf(n, "%s: esperado objeto, encontrado %s", path, nodeKind(n))
+			return
+		}
+		m := reflect.MakeMap(v.Type())
+		seen := map[string]*yaml.Node{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if prev, ok := seen[key.Value]; ok {
+				d.errorf(key, "chave duplicada %q (já definida na linha %d)", key.Value, prev.Line)
+				continue
+			}
+			seen[key.Value] = key
+			elem := reflect.New(v.Type().Elem()).Elem()
+			d.decode(value, elem, joinPath(path, key.Value))
+			m.SetMapIndex(reflect.ValueOf(key.Value), elem)
+		}
+		v.Set(m)
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode {
+			d.errorf(n, "%s: esperado texto, encontrado %s", path, nodeKind(n))
+			return
+		}
+		v.SetString(n.Value)
+	case reflect.Int, reflect.Int64, reflect.Int32:
+		var i int64
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!int" || n.Decode(&i) != nil {
+			d.errorf(n, "%s: esperado número inteiro, encontrado %s", path, nodeKind(n))
+			return
+		}
+		v.SetInt(i)
+	case reflect.Bool:
+		var b bool
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!bool" || n.Decode(&b) != nil {
+			d.errorf(n, "%s: esperado booleano, encontrado %s", path, nodeKind(n))
+			return
+		}
+		v.SetBool(b)
+	default:
+		if err := n.Decode(v.Addr().Interface()); err != nil {
+			d.errorf(n, "%s: %v", path, err)
+		}
+	}
+}
+
+func (d *nodeDecoder) decodeStruct(n *yaml.Node, v reflect.Value, path string) {
+	if n.Kind != yaml.MappingNode {
+		d.errorf(n, "%s: esperado objeto, encontrado %s", path, nodeKind(n))
+		return
+	}
+
+	fields := map[string]int{}
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+
+	seen := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if prev, ok := seen[key.Value]; ok {
+			d.errorf(key, "chave duplicada %q (já definida na linha %d)", key.Value, prev.Line)
+			continue
+		}
+		seen[key.Value] = key
+
+		idx, ok := fields[key.Value]
+		if !ok {
+			if !d.strict {
+				continue
+			}
+			d.errorf(key, "campo desconhecido %q em %s", key.Value, describePath(path))
+			continue
+		}
+		d.decode(value, v.Field(idx), joinPath(path, key.Value))
+	}
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "raiz"
+	}
+	return path
+}
+
+func nodeKind(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "objeto"
+	case yaml.SequenceNode:
+		return "lista"
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!str":
+			return fmt.Sprintf("texto %q", n.Value)
+		case "!!int", "!!float":
+			return "número " + n.Value
+		case "!!bool":
+			return "booleano " + n.Value
+		}
+		return n.Value
+	}
+	return "valor inválido"
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected Format
+	}{
+		{"config.json", "servers: []", FormatJSON},
+		{"config.yml", `{"servers": []}`, FormatYAML},
+		{"config", `  {"servers": []}`, FormatJSON},
+		{"config", "servers: []", FormatYAML},
+	}
+	for _, c := range cases {
+		if got := DetectFormat(c.name, []byte(c.data)); got != c.expected {
+			t.Errorf("DetectFormat(%q) = %s, esperado %s", c.name, got, c.expected)
+		}
+	}
+}
+
+func TestDecodeStrictErrors(t *testing.T) {
+	doc := `servers:
+  - name: app
+    host: localhost
+    port: abc
+    helthcheck: /health
+    name: outro
+database:
+  host: localhost
+`
+	_, err := Decode("config.yaml", []byte(doc))
+	var errs DecodeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Esperado DecodeErrors, obtido %v", err)
+	}
+
+	expected := []string{
+		`config.yaml:4:11: servers[0].port: esperado número inteiro`,
+		`config.yaml:5:5: campo desconhecido "helthcheck"`,
+		`config.yaml:6:5: chave duplicada "name"`,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Esperados %d erros, obtidos %d: %v", len(expected), len(errs), err)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(errs[i].Error(), prefix) {
+			t.Errorf("Erro %d = %q, esperado prefixo %q", i, errs[i].Error(), prefix)
+		}
+	}
+}
+
+func TestDecodeJSONSyntaxPosition(t *testing.T) {
+	doc := "{\n  \"servers\": [\n    {\"name\": \"a\",}\n  ]\n}"
+	_, err := Decode("config.json", []byte(doc))
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Esperado DecodeError, obtido %v", err)
+	}
+	if decodeErr.Line != 3 {
+		t.Errorf("Linha esperada 3, obtida %d", decodeErr.Line)
+	}
+}
+
+func TestDecodeJSONEscapes(t *testing.T) {
+	doc := "{\"servers\": [{\"name\": \"a\\/b\", \"host\": \"\\u006cocalhost\", \"port\": 80,\n  \"helthcheck\": \"/\"}]}"
+	_, err := Decode("config.json", []byte(doc))
+	var errs DecodeErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Line != 2 || errs[0].Column != 3 {
+		t.Fatalf("Esperado somente o campo desconhecido em 2:3, obtido %v", err)
+	}
+
+	cfg, err := Decode("config.json", []byte(strings.Replace(doc, "helthcheck", "healthcheck", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := cfg.Servers[0]; s.Name != "a/b" || s.Host != "localhost" || s.Port != 80 {
+		t.Errorf("Servidor decodificado incorretamente: %+v", s)
+	}
+}
+
+func TestDecodeExampleConfig(t *testing.T) {
+	doc := `{"servers": [{"name": "app", "host": "localhost", "port": 8080, "replicas": 2}],
+	"database": {"host": "localhost", "port": 5432, "user": "admin", "password": "secret"}}`
+	cfg, err := Decode("config.json", []byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Servers[0].Port != 8080 || cfg.Servers[0].Replicas != 2 || cfg.Database.User != "admin" {
+		t.Errorf("Configuração decodificada incorretamente: %+v", cfg)
+	}
+}
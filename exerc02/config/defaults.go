@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServerDefaults são os campos de ServerConfig que podem ter valor padrão.
+type ServerDefaults struct {
+	Host        string            `json:"host,omitempty" yaml:"host,omitempty"`
+	Port        int               `json:"port,omitempty" yaml:"port,omitempty" jsonschema:"minimum=1,maximum=65535"`
+	Replicas    int               `json:"replicas,omitempty" yaml:"replicas,omitempty" jsonschema:"minimum=0"`
+	Healthcheck string            `json:"healthcheck,omitempty" yaml:"healthcheck,omitempty"`
+	Protocol    string            `json:"protocol,omitempty" yaml:"protocol,omitempty" jsonschema:"enum=http|https"`
+	Tags        []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Interval    string            `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+// WebsiteDefaults são os campos de WebsiteConfig que podem ter valor padrão.
+type WebsiteDefaults struct {
+	MaxResponseTime int               `json:"max_response_time,omitempty" yaml:"max_response_time,omitempty" jsonschema:"minimum=1"`
+	Tags            []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// GroupDefaults são os padrões aplicados às entradas que têm a tag do grupo.
+type GroupDefaults struct {
+	Servers  *ServerDefaults  `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Websites *WebsiteDefaults `json:"websites,omitempty" yaml:"websites,omitempty"`
+}
+
+// ConfigDefaults é a seção defaults do arquivo. Os valores são mesclados em cada
+// entrada durante o carregamento, do mais geral para o mais específico: defaults,
+// os grupos das tags da entrada (na ordem das tags) e a própria entrada.
+type ConfigDefaults struct {
+	Servers  *ServerDefaults          `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Websites *WebsiteDefaults         `json:"websites,omitempty" yaml:"websites,omitempty"`
+	Groups   map[string]GroupDefaults `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// Origens possíveis de um valor, além dos caminhos dentro de defaults.
+const (
+	SourceEntry    = "entrada"
+	SourceDefaults = "padrão do programa"
+)
+
+// Origin indica de onde veio o valor efetivo de um campo de servidor ou website.
+type Origin struct {
+	Path   string `json:"path" yaml:"path"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// defaultsLayer é uma camada de valores mesclada em uma entrada.
+type defaultsLayer struct {
+	source string
+	node   *yaml.Node
+}
+
+// resolveDefaults remove a seção defaults da árvore e a mescla em cada servidor e
+// website. Retorna também a origem de cada campo preenchido, indexada pelo caminho.
+func resolveDefaults(root *yaml.Node) (*yaml.Node, map[string]string) {
+	root = unwrapDocument(root)
+	sources := map[string]string{}
+	if root.Kind != yaml.MappingNode {
+		return root, sources
+	}
+
+	var defaults *yaml.Node
+	resolved := *root
+	resolved.Content = nil
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "defaults" {
+			defaults = resolveAlias(root.Content[i+1])
+			continue
+		}
+		resolved.Content = append(resolved.Content, root.Content[i], root.Content[i+1])
+	}
+
+	for _, section := range []string{"servers", "websites"} {
+		idx := mappingIndex(&resolved, section)
+		if idx < 0 || resolveAlias(resolved.Content[idx+1]).Kind != yaml.SequenceNode {
+			continue
+		}
+		seq := *resolveAlias(resolved.Content[idx+1])
+		seq.Content = slices.Clone(seq.Content)
+		for i, item := range seq.Content {
+			item = resolveAlias(item)
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			path := fmt.Sprintf("%s[%d]", section, i)
+			layers := append(defaultsLayers(defaults, section, item), defaultsLayer{SourceEntry, item})
+			var merged *yaml.Node
+			for _, l := range layers {
+				recordSources(sources, path, l)
+				merged = MergeNodes(merged, l.node)
+			}
+			seq.Content[i] = merged
+		}
+		resolved.Content[idx+1] = &seq
+	}
+	return &resolved, sources
+}
+
+// defaultsLayers retorna os padrões que se aplicam a item, do mais geral para o
+// mais específico.
+func defaultsLayers(defaults *yaml.Node, section string, item *yaml.Node) []defaultsLayer {
+	if defaults == nil || defaults.Kind != yaml.MappingNode {
+		return nil
+	}
+	var layers []defaultsLayer
+	if d := mappingValue(defaults, section); d != nil && resolveAlias(d).Kind == yaml.MappingNode {
+		layers = append(layers, defaultsLayer{"defaults." + section, resolveAlias(d)})
+	}
+
+	groups, tags := mappingValue(defaults, "groups"), mappingValue(item, "tags")
+	if groups == nil || tags == nil || resolveAlias(groups).Kind != yaml.MappingNode || resolveAlias(tags).Kind != yaml.SequenceNode {
+		return layers
+	}
+	for _, tag := range resolveAlias(tags).Content {
+		group := mappingValue(resolveAlias(groups), tag.Value)
+		if group == nil || resolveAlias(group).Kind != yaml.MappingNode {
+			continue
+		}
+		if d := mappingValue(resolveAlias(group), section); d != nil && resolveAlias(d).Kind == yaml.MappingNode {
+			layers = append(layers, defaultsLayer{fmt.Sprintf("defaults.groups.%s.%s", tag.Value, section), resolveAlias(d)})
+		}
+	}
+	return layers
+}
+
+// recordSources marca os campos da camada como vindos dela. Os labels são
+// mesclados chave a chave, então cada chave tem a sua origem.
+func recordSources(sources map[string]string, path string, l defaultsLayer) {
+	for i := 0; i+1 < len(l.node.Content); i += 2 {
+		key, value := l.node.Content[i].Value, resolveAlias(l.node.Content[i+1])
+		if value.Kind == yaml.MappingNode {
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				sources[path+"."+key+"."+value.Content[j].Value] = l.source
+			}
+			continue
+		}
+		sources[path+"."+key] = l.source
+	}
+}
+
+// origins lista, na ordem dos campos de ServerConfig e WebsiteConfig, o valor
+// efetivo de cada campo informado e a sua origem. Campos sem origem registrada
+// vieram de WithDefaults.
+func origins(root *yaml.Node, sources map[string]string) []Origin {
+	root = unwrapDocument(root)
+	var out []Origin
+	add := func(path string, value *yaml.Node) {
+		source, ok := sources[path]
+		if !ok {
+			source = SourceDefaults
+		}
+		out = append(out, Origin{Path: path, Value: originValue(value), Source: source})
+	}
+
+	sections := []struct {
+		key string
+		t   reflect.Type
+	}{{"servers", reflect.TypeOf(ServerConfig{})}, {"websites", reflect.TypeOf(WebsiteConfig{})}}
+	for _, section := range sections {
+		seq := mappingValue(root, section.key)
+		if seq == nil || resolveAlias(seq).Kind != yaml.SequenceNode {
+			continue
+		}
+		for i, item := range resolveAlias(seq).Content {
+			item = resolveAlias(item)
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			for f := 0; f < section.t.NumField(); f++ {
+				name := strings.Split(section.t.Field(f).Tag.Get("yaml"), ",")[0]
+				value := mappingValue(item, name)
+				if value == nil {
+					continue
+				}
+				path := fmt.Sprintf("%s[%d].%s", section.key, i, name)
+				if value = resolveAlias(value); value.Kind != yaml.MappingNode {
+					add(path, value)
+					continue
+				}
+				keys := make([]string, 0, len(value.Content)/2)
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					keys = append(keys, value.Content[j].Value)
+				}
+				slices.Sort(keys)
+				for _, k := range keys {
+					add(path+"."+k, mappingValue(value, k))
+				}
+			}
+		}
+	}
+	return out
+}
+
+func originValue(n *yaml.Node) string {
+	n = resolveAlias(n)
+	if n.Kind != yaml.SequenceNode {
+		return n.Value
+	}
+	items := make([]string, len(n.Content))
+	for i, item := range n.Content {
+		items[i] = resolveAlias(item).Value
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
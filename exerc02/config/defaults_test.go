@@ -0,0 +1,88 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const defaultsConfig = `defaults:
+  servers:
+    port: 443
+    replicas: 3
+    protocol: https
+    labels:
+      env: prod
+  websites:
+    max_response_time: 2000
+  groups:
+    internal:
+      servers:
+        port: 8080
+        protocol: http
+        labels:
+          tier: backend
+servers:
+  - name: web
+    host: web.local
+  - name: api
+    host: api.local
+    replicas: 1
+    tags: [internal]
+database:
+  host: localhost
+  port: 5432
+  user: admin
+websites:
+  - name: GitHub
+    url: https://github.com
+`
+
+func TestLoadDefaults(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", defaultsConfig)
+
+	var origins []Origin
+	cfg, err := Load(path,
+		WithDefaults(Defaults{Server: ServerConfig{Healthcheck: "/"}}),
+		WithOrigins(func(o Origin) { origins = append(origins, o) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Defaults != nil {
+		t.Errorf("A seção defaults deve ser removida após a resolução: %+v", cfg.Defaults)
+	}
+
+	web, api := cfg.Servers[0], cfg.Servers[1]
+	if web.Port != 443 || web.Replicas != 3 || web.Protocol != "https" || web.Labels["env"] != "prod" {
+		t.Errorf("Padrões não aplicados: %+v", web)
+	}
+	if api.Port != 8080 || api.Replicas != 1 || api.Protocol != "http" || api.Labels["env"] != "prod" || api.Labels["tier"] != "backend" {
+		t.Errorf("Padrões do grupo não aplicados: %+v", api)
+	}
+	if cfg.Website[0].MaxResponseTime != 2000 {
+		t.Errorf("Padrão do website não aplicado: %+v", cfg.Website[0])
+	}
+
+	expected := []Origin{
+		{Path: "servers[1].port", Value: "8080", Source: "defaults.groups.internal.servers"},
+		{Path: "servers[1].replicas", Value: "1", Source: SourceEntry},
+		{Path: "servers[1].healthcheck", Value: "/", Source: SourceDefaults},
+		{Path: "servers[1].labels.env", Value: "prod", Source: "defaults.servers"},
+		{Path: "servers[1].tags", Value: "[internal]", Source: SourceEntry},
+		{Path: "websites[0].max_response_time", Value: "2000", Source: "defaults.websites"},
+	}
+	for _, o := range expected {
+		if !slices.Contains(origins, o) {
+			t.Errorf("Origem ausente: %+v", o)
+		}
+	}
+}
+
+func TestLoadDefaultsInvalidField(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", strings.Replace(defaultsConfig, "    port: 443\n", "    name: x\n", 1))
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), `campo desconhecido "name"`) {
+		t.Error("Esperado erro para campo sem valor padrão em defaults.servers")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+// FieldChange descreve a alteração de um campo entre duas versões de uma entrada.
+type FieldChange struct {
+	Field string `json:"field" yaml:"field"`
+	Old   any    `json:"old" yaml:"old"`
+	New   any    `json:"new" yaml:"new"`
+}
+
+// Change descreve uma entrada (servidor, website ou banco de dados) que mudou.
+type Change struct {
+	Section string        `json:"section" yaml:"section"`
+	Name    string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Type    ChangeType    `json:"type" yaml:"type"`
+	Fields  []FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+func (c Change) String() string {
+	symbol := map[ChangeType]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeChanged: "~"}[c.Type]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", symbol, c.Section)
+	if c.Name != "" {
+		fmt.Fprintf(&b, " %s", c.Name)
+	}
+	for _, f := range c.Fields {
+		fmt.Fprintf(&b, "\n    %s: %s → %s", f.Field, formatDiffValue(f.Old), formatDiffValue(f.New))
+	}
+	return b.String()
+}
+
+func formatDiffValue(v any) string {
+	if s, ok := v.(string); ok && s == "" {
+		return `""`
+	}
+	return fmt.Sprint(v)
+}
+
+// Diff compara semanticamente duas configurações. Servidores e websites são
+// casados pelo name; campos secretos aparecem mascarados.
+func Diff(old, new Config) []Change {
+	var changes []Change
+	changes = append(changes, diffNamed("server", old.Servers, new.Servers, func(s ServerConfig) string { return s.Name })...)
+	if fields := diffFields(reflect.ValueOf(old.Database), reflect.ValueOf(new.Database)); len(fields) > 0 {
+		changes = append(changes, Change{Section: "database", Type: ChangeChanged, Fields: fields})
+	}
+	changes = append(changes, diffNamed("website", old.Website, new.Website, func(w WebsiteConfig) string { return w.Name })...)
+	return changes
+}
+
+func diffNamed[T any](section string, old, new []T, name func(T) string) []Change {
+	var changes []Change
+	newByName := map[string]T{}
+	for _, item := range new {
+		if _, ok := newByName[name(item)]; !ok {
+			newByName[name(item)] = item
+		}
+	}
+	oldNames := map[string]bool{}
+
+	for _, item := range old {
+		n := name(item)
+		if oldNames[n] {
+			continue
+		}
+		oldNames[n] = true
+		updated, ok := newByName[n]
+		if !ok {
+			changes = append(changes, Change{Section: section, Name: n, Type: ChangeRemoved})
+			continue
+		}
+		if fields := diffFields(reflect.ValueOf(item), reflect.ValueOf(updated)); len(fields) > 0 {
+			changes = append(changes, Change{Section: section, Name: n, Type: ChangeChanged, Fields: fields})
+		}
+	}
+	for _, item := range new {
+		if n := name(item); !oldNames[n] {
+			oldNames[n] = true
+			changes = append(changes, Change{Section: section, Name: n, Type: ChangeAdded})
+		}
+	}
+	return changes
+}
+
+func diffFields(old, new reflect.Value) []FieldChange {
+	var fields []FieldChange
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		a, b := old.Field(i).Interface(), new.Field(i).Interface()
+		if reflect.DeepEqual(a, b) {
+			continue
+		}
+		if field.Tag.Get("secret") == "true" {
+			a, b = RedactedValue, RedactedValue
+		}
+		fields = append(fields, FieldChange{Field: strings.Split(field.Tag.Get("json"), ",")[0], Old: a, New: b})
+	}
+	return fields
+}
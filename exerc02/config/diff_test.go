@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := Config{
+		Servers: []ServerConfig{
+			{Name: "app", Host: "app.local", Port: 8080, Replicas: 3},
+			{Name: "legacy", Host: "legacy.local", Port: 80},
+		},
+		Database: DatabaseConfig{Host: "localhost", Password: "old"},
+		Website:  []WebsiteConfig{{Name: "Example", Url: "https://example.com", MaxResponseTime: 100}},
+	}
+	new := Config{
+		Servers: []ServerConfig{
+			{Name: "app", Host: "app.local", Port: 8080, Replicas: 5},
+			{Name: "worker", Host: "worker.local", Port: 7070},
+		},
+		Database: DatabaseConfig{Host: "localhost", Password: "new"},
+		Website:  []WebsiteConfig{{Name: "Example", Url: "https://example.com", MaxResponseTime: 100}},
+	}
+
+	changes := Diff(old, new)
+	if len(changes) != 4 {
+		t.Fatalf("Esperadas 4 mudanças, obtidas %d: %v", len(changes), changes)
+	}
+
+	app := changes[0]
+	if app.Name != "app" || app.Type != ChangeChanged || len(app.Fields) != 1 {
+		t.Fatalf("Mudança inesperada para app: %+v", app)
+	}
+	if f := app.Fields[0]; f.Field != "replicas" || f.Old != 3 || f.New != 5 {
+		t.Errorf("Campo alterado inesperado: %+v", f)
+	}
+	if app.String() != "~ server app\n    replicas: 3 → 5" {
+		t.Errorf("Texto inesperado: %q", app.String())
+	}
+
+	if changes[1].Name != "legacy" || changes[1].Type != ChangeRemoved {
+		t.Errorf("legacy deveria ser removido: %+v", changes[1])
+	}
+	if changes[2].Name != "worker" || changes[2].Type != ChangeAdded {
+		t.Errorf("worker deveria ser adicionado: %+v", changes[2])
+	}
+
+	db := changes[3]
+	if db.Section != "database" || db.Fields[0].Old != RedactedValue || db.Fields[0].New != RedactedValue {
+		t.Errorf("Senha deveria aparecer mascarada no diff: %+v", db)
+	}
+}
+
+func TestDiffIdentical(t *testing.T) {
+	cfg := Config{Servers: []ServerConfig{{Name: "app", Port: 80}}}
+	if changes := Diff(cfg, cfg); len(changes) != 0 {
+		t.Errorf("Configurações iguais não devem ter diferenças: %v", changes)
+	}
+}
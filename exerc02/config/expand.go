@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortRange é o intervalo de portas, inclusivo, usado pelas instâncias criadas
+// por Expand. O valor zero aloca portas consecutivas a partir da porta do servidor.
+type PortRange struct {
+	Start int `json:"start" yaml:"start"`
+	End   int `json:"end" yaml:"end"`
+}
+
+func (r PortRange) String() string {
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// ParsePortRange lê um intervalo no formato inicio-fim, como 20000-20999.
+func ParsePortRange(s string) (PortRange, error) {
+	start, end, ok := strings.Cut(s, "-")
+	r := PortRange{}
+	var err1, err2 error
+	r.Start, err1 = strconv.Atoi(strings.TrimSpace(start))
+	r.End, err2 = strconv.Atoi(strings.TrimSpace(end))
+	if !ok || err1 != nil || err2 != nil {
+		return PortRange{}, fmt.Errorf("intervalo de portas inválido %q (use inicio-fim)", s)
+	}
+	if r.Start < 1 || r.End > 65535 || r.Start > r.End {
+		return PortRange{}, fmt.Errorf("intervalo de portas inválido %q: deve estar entre 1 e 65535", s)
+	}
+	return r, nil
+}
+
+// Expand troca cada servidor com mais de uma réplica por instâncias concretas
+// name-1..name-N, cada uma com replicas 1 e sua própria porta. Servidores com
+// replicas 0 ou 1 são mantidos como estão.
+//
+// As portas são alocadas em ordem (servidores na ordem do arquivo, depois as
+// instâncias) a partir de r, pulando as portas declaradas pelos demais servidores
+// no mesmo host; sem intervalo, cada servidor usa as portas seguintes à sua.
+// Instâncias que colidiriam com outro servidor são retornadas como ValidationErrors.
+func Expand(cfg Config, r PortRange) (Config, error) {
+	used := map[string]string{}
+	for _, s := range cfg.Servers {
+		if _, ok := used[hostPort(s.Host, s.Port)]; !ok {
+			used[hostPort(s.Host, s.Port)] = s.Name
+		}
+	}
+
+	var errs ValidationErrors
+	next := r.Start
+	expanded := cfg
+	expanded.Servers = nil
+	for i, s := range cfg.Servers {
+		if s.Replicas <= 1 {
+			expanded.Servers = append(expanded.Servers, s)
+			continue
+		}
+
+		path := fmt.Sprintf("servers[%d]", i)
+		for n := 1; n <= s.Replicas; n++ {
+			instance := s
+			instance.Name = fmt.Sprintf("%s-%d", s.Name, n)
+			instance.Replicas = 1
+
+			if r == (PortRange{}) {
+				port := s.Port + n - 1
+				owner, ok := used[hostPort(s.Host, port)]
+				if port > 65535 {
+					errs.add(path+".replicas", "port-range",
+						fmt.Sprintf("%s: porta %d fora do intervalo 1-65535", instance.Name, port), SeverityError)
+					continue
+				}
+				if ok && (n > 1 || owner != s.Name) {
+					errs.add(path+".port", "port-conflict",
+						fmt.Sprintf("%s: porta %d já usada por %s", instance.Name, port, owner), SeverityError)
+					continue
+				}
+				instance.Port = port
+			} else {
+				for next <= r.End {
+					if _, ok := used[hostPort(s.Host, next)]; !ok {
+						break
+					}
+					next++
+				}
+				if next > r.End {
+					errs.add(path+".replicas", "port-range",
+						fmt.Sprintf("%s: intervalo %s esgotado", instance.Name, r), SeverityError)
+					continue
+				}
+				instance.Port = next
+				next++
+			}
+
+			used[hostPort(s.Host, instance.Port)] = instance.Name
+			expanded.Servers = append(expanded.Servers, instance)
+		}
+	}
+
+	if errs.HasErrors() {
+		return Config{}, errs
+	}
+	return expanded, nil
+}
+
+func hostPort(host string, port int) string {
+	return strings.ToLower(host) + ":" + strconv.Itoa(port)
+}
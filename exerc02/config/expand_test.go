@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandConsecutivePorts(t *testing.T) {
+	cfg := Config{Servers: []ServerConfig{
+		{Name: "app", Host: "localhost", Port: 8080, Replicas: 3, Protocol: "http"},
+		{Name: "db", Host: "localhost", Port: 5432},
+	}}
+
+	expanded, err := Expand(cfg, PortRange{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"app-1 at localhost:8080", "app-2 at localhost:8081", "app-3 at localhost:8082", "db at localhost:5432"}
+	if len(expanded.Servers) != len(expected) {
+		t.Fatalf("Instâncias inesperadas: %v", expanded.Servers)
+	}
+	for i, s := range expanded.Servers {
+		if s.String() != expected[i] {
+			t.Errorf("Instância %d: esperado %s, obtido %s", i, expected[i], s)
+		}
+		if s.Name != "db" && (s.Replicas != 1 || s.Protocol != "http") {
+			t.Errorf("Instância deve herdar os campos do servidor: %+v", s)
+		}
+	}
+}
+
+func TestExpandPortRange(t *testing.T) {
+	r, err := ParsePortRange("9000-9004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Servers: []ServerConfig{
+		{Name: "app", Host: "localhost", Port: 80, Replicas: 2},
+		{Name: "fixed", Host: "localhost", Port: 9001},
+		{Name: "api", Host: "localhost", Port: 81, Replicas: 2},
+	}}
+
+	expanded, err := Expand(cfg, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports := []int{9000, 9002, 9001, 9003, 9004}
+	for i, s := range expanded.Servers {
+		if s.Port != ports[i] {
+			t.Errorf("%s: esperado porta %d, obtido %d", s.Name, ports[i], s.Port)
+		}
+	}
+
+	cfg.Servers[2].Replicas = 3
+	var errs ValidationErrors
+	if _, err := Expand(cfg, r); !errors.As(err, &errs) || errs[0].Rule != "port-range" {
+		t.Errorf("Esperado erro de intervalo esgotado: %v", err)
+	}
+}
+
+func TestExpandConflict(t *testing.T) {
+	cfg := Config{Servers: []ServerConfig{
+		{Name: "app", Host: "localhost", Port: 8080, Replicas: 2},
+		{Name: "api", Host: "localhost", Port: 8081},
+	}}
+
+	var errs ValidationErrors
+	if _, err := Expand(cfg, PortRange{}); !errors.As(err, &errs) || errs[0].Path != "servers[0].port" || errs[0].Rule != "port-conflict" {
+		t.Errorf("Esperado conflito com api: %v", err)
+	}
+
+	cfg.Servers[1].Host = "other"
+	if _, err := Expand(cfg, PortRange{}); err != nil {
+		t.Errorf("Hosts diferentes não conflitam: %v", err)
+	}
+
+	for _, s := range []string{"9000", "9000-8000", "0-10", "a-b"} {
+		if _, err := ParsePortRange(s); err == nil {
+			t.Errorf("%q: era esperado erro", s)
+		}
+	}
+}
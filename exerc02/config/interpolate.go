@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var referenceRe = regexp.MustCompile(`\$\$|\$\{([^}]*)\}`)
+
+// Interpolator expande referências ${VAR}, ${VAR:-padrão} e ${file:/caminho}
+// nos valores da configuração.
+type Interpolator struct {
+	LookupEnv    func(string) (string, bool)
+	ReadFile     func(string) ([]byte, error)
+	AllowMissing bool
+}
+
+// NewInterpolator cria um Interpolator que lê do ambiente e do sistema de arquivos.
+func NewInterpolator(allowMissing bool) Interpolator {
+	return Interpolator{
+		LookupEnv:    os.LookupEnv,
+		ReadFile:     os.ReadFile,
+		AllowMissing: allowMissing,
+	}
+}
+
+// Expand resolve as referências de s. Referências não resolvidas são substituídas
+// por texto vazio e retornadas em missing.
+func (in Interpolator) Expand(s string) (result string, missing []string) {
+	result = referenceRe.ReplaceAllStringFunc(s, func(match string) string {
+		if match == "$$" {
+			return "$"
+		}
+		ref := match[2 : len(match)-1]
+
+		if path, ok := strings.CutPrefix(ref, "file:"); ok {
+			data, err := in.ReadFile(path)
+			if err != nil {
+				missing = append(missing, ref)
+				return ""
+			}
+			return strings.TrimRight(string(data), "\r\n")
+		}
+
+		name, def, hasDefault := strings.Cut(ref, ":-")
+		if value, ok := in.LookupEnv(name); ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return def
+		}
+		missing = append(missing, name)
+		return ""
+	})
+	return result, missing
+}
+
+// ExpandNode aplica Expand a todos os valores escalares da árvore e retorna as
+// referências ausentes como erros de validação (ou avisos, com AllowMissing).
+func (in Interpolator) ExpandNode(root *yaml.Node) ValidationErrors {
+	var errs ValidationErrors
+	in.expandNode(root, "", &errs)
+	return errs
+}
+
+func (in Interpolator) expandNode(n *yaml.Node, path string, errs *ValidationErrors) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			in.expandNode(c, path, errs)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			in.expandNode(n.Content[i+1], joinPath(path, n.Content[i].Value), errs)
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			in.expandNode(c, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(n.Value, "$") {
+			return
+		}
+		value, missing := in.Expand(n.Value)
+		severity := SeverityError
+		if in.AllowMissing {
+			severity = SeverityWarning
+		}
+		for _, ref := range missing {
+			msg := fmt.Sprintf("variável de ambiente %s não definida", ref)
+			if strings.HasPrefix(ref, "file:") {
+				msg = fmt.Sprintf("arquivo %s não pôde ser lido", strings.TrimPrefix(ref, "file:"))
+			}
+			errs.add(path, "env", msg, severity)
+		}
+		if value != n.Value {
+			// O valor expandido define o tipo: "${PORT}" pode virar um inteiro.
+			n.Value = value
+			n.Tag = ""
+			n.Style = 0
+		}
+	}
+}
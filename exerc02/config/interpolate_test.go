@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func testInterpolator(allowMissing bool) Interpolator {
+	env := map[string]string{"DB_HOST": "db.local", "DB_PORT": "5432", "EMPTY": ""}
+	files := map[string]string{"/run/secrets/db": "s3cr3t\n"}
+	return Interpolator{
+		LookupEnv: func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		},
+		ReadFile: func(path string) ([]byte, error) {
+			if v, ok := files[path]; ok {
+				return []byte(v), nil
+			}
+			return nil, errors.New("not found")
+		},
+		AllowMissing: allowMissing,
+	}
+}
+
+func TestInterpolatorExpand(t *testing.T) {
+	in := testInterpolator(false)
+	cases := map[string]string{
+		"${DB_HOST}":              "db.local",
+		"jdbc://${DB_HOST}:5432":  "jdbc://db.local:5432",
+		"${EMPTY:-fallback}":      "fallback",
+		"${UNSET:-fallback}":      "fallback",
+		"${file:/run/secrets/db}": "s3cr3t",
+		"$${DB_HOST}":             "${DB_HOST}",
+	}
+	for input, expected := range cases {
+		got, missing := in.Expand(input)
+		if got != expected || len(missing) != 0 {
+			t.Errorf("Expand(%q) = %q %v, esperado %q", input, got, missing, expected)
+		}
+	}
+
+	if _, missing := in.Expand("${UNSET}"); len(missing) != 1 || missing[0] != "UNSET" {
+		t.Errorf("Variável ausente não reportada: %v", missing)
+	}
+}
+
+func TestInterpolatorExpandNode(t *testing.T) {
+	doc := `database:
+  host: ${DB_HOST}
+  port: ${DB_PORT}
+  user: ${DB_USER}
+  password: ${file:/run/secrets/db}
+`
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(doc), &root); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := testInterpolator(false).ExpandNode(&root)
+	if len(errs) != 1 || errs[0].Path != "database.user" || errs[0].Severity != SeverityError {
+		t.Fatalf("Esperado erro para database.user, obtido %v", errs)
+	}
+
+	var cfg Config
+	if err := DecodeNode("config.yaml", &root, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Host != "db.local" || cfg.Database.Port != 5432 || cfg.Database.Password != "s3cr3t" {
+		t.Errorf("Valores interpolados incorretos: %+v", cfg.Database)
+	}
+}
+
+func TestInterpolatorAllowMissing(t *testing.T) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte("database:\n  user: ${DB_USER}\n"), &root); err != nil {
+		t.Fatal(err)
+	}
+	errs := testInterpolator(true).ExpandNode(&root)
+	if errs.HasErrors() || len(errs) != 1 {
+		t.Errorf("Variáveis ausentes deveriam ser apenas avisos: %v", errs)
+	}
+}
@@ -0,0 +1,457 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InventoryFields são os campos de ServerConfig que podem ser importados de uma
+// planilha ou inventário.
+var InventoryFields = []string{"name", "host", "port", "protocol", "healthcheck", "replicas", "tags"}
+
+// CSVOptions controla a leitura de uma planilha com ImportCSV.
+type CSVOptions struct {
+	// Columns associa um campo de InventoryFields ao cabeçalho da coluna; campos
+	// sem associação são procurados por uma coluna com o próprio nome.
+	Columns map[string]string
+	Comma   rune
+}
+
+// ImportCSV lê uma planilha com cabeçalho, uma linha por servidor. As linhas que
+// não puderam ser convertidas são retornadas como ValidationErrors, junto com a
+// configuração montada a partir das demais.
+func ImportCSV(filename string, r io.Reader, opts CSVOptions) (Config, error) {
+	filename = displayName(filename)
+	for field := range opts.Columns {
+		if !slices.Contains(InventoryFields, field) {
+			return Config{}, fmt.Errorf("campo desconhecido %q (use %s)", field, strings.Join(InventoryFields, ", "))
+		}
+	}
+
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return Config{}, errors.New(filename + ": planilha vazia")
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	columns := map[string]int{}
+	for _, field := range InventoryFields {
+		name, mapped := opts.Columns[field]
+		if !mapped {
+			name = field
+		}
+		i := slices.IndexFunc(header, func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), name) })
+		if i < 0 && mapped {
+			return Config{}, fmt.Errorf("%s: coluna %q não encontrada no cabeçalho", filename, name)
+		}
+		if i >= 0 {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["host"]; !ok {
+		return Config{}, fmt.Errorf("%s: nenhuma coluna para host (use o mapeamento de colunas)", filename)
+	}
+
+	cfg := Config{APIVersion: APIVersionV2, Kind: KindConfig}
+	var errs ValidationErrors
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			errs.add(fmt.Sprintf("%s:%d", filename, parseErr.StartLine), "import", parseErr.Err.Error(), SeverityError)
+			continue
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", filename, err)
+		}
+		line, _ := reader.FieldPos(0)
+		path := fmt.Sprintf("%s:%d", filename, line)
+
+		fields := map[string]string{}
+		for field, i := range columns {
+			if i < len(record) {
+				fields[field] = strings.TrimSpace(record[i])
+			}
+		}
+		tags := strings.FieldsFunc(fields["tags"], func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+		server, err := inventoryServer(fields, tags)
+		if err != nil {
+			errs.add(path, "import", err.Error(), SeverityError)
+			continue
+		}
+		cfg.Servers = append(cfg.Servers, server)
+	}
+
+	if len(errs) > 0 {
+		return cfg, errs
+	}
+	return cfg, nil
+}
+
+// inventoryServer monta um servidor a partir dos campos importados. Sem protocol,
+// a porta 443 indica https e as demais http.
+func inventoryServer(fields map[string]string, tags []string) (ServerConfig, error) {
+	s := ServerConfig{Name: fields["name"], Host: fields["host"], Healthcheck: fields["healthcheck"], Protocol: strings.ToLower(fields["protocol"]), Tags: tags}
+	if s.Host == "" {
+		return s, errors.New("host não informado")
+	}
+	if s.Name == "" {
+		s.Name = s.Host
+	}
+	if fields["port"] == "" {
+		return s, fmt.Errorf("%s: porta não informada", s.Name)
+	}
+	port, err := strconv.Atoi(fields["port"])
+	if err != nil || port < 1 || port > 65535 {
+		return s, fmt.Errorf("%s: porta inválida %q", s.Name, fields["port"])
+	}
+	s.Port = port
+	if r := fields["replicas"]; r != "" {
+		if s.Replicas, err = strconv.Atoi(r); err != nil || s.Replicas < 0 {
+			return s, fmt.Errorf("%s: replicas inválido %q", s.Name, r)
+		}
+	}
+	switch s.Protocol {
+	case "":
+		s.Protocol = "http"
+		if s.Port == 443 {
+			s.Protocol = "https"
+		}
+	case "http", "https":
+	default:
+		return s, fmt.Errorf("%s: protocolo desconhecido %q", s.Name, fields["protocol"])
+	}
+	return s, nil
+}
+
+// ansibleInventory guarda hosts e grupos na ordem em que aparecem no inventário.
+type ansibleInventory struct {
+	hosts  []string
+	vars   map[string]map[string]string
+	groups map[string]*ansibleGroup
+	// member associa cada host aos grupos em que foi declarado.
+	member map[string][]string
+}
+
+type ansibleGroup struct {
+	vars    map[string]string
+	parents []string
+}
+
+func newAnsibleInventory() *ansibleInventory {
+	return &ansibleInventory{vars: map[string]map[string]string{}, groups: map[string]*ansibleGroup{}, member: map[string][]string{}}
+}
+
+func (inv *ansibleInventory) group(name string) *ansibleGroup {
+	g, ok := inv.groups[name]
+	if !ok {
+		g = &ansibleGroup{vars: map[string]string{}}
+		inv.groups[name] = g
+	}
+	return g
+}
+
+func (inv *ansibleInventory) addHost(group, host string, vars map[string]string) {
+	if _, ok := inv.vars[host]; !ok {
+		inv.hosts = append(inv.hosts, host)
+		inv.vars[host] = map[string]string{}
+	}
+	for k, v := range vars {
+		inv.vars[host][k] = v
+	}
+	if !slices.Contains(inv.member[host], group) {
+		inv.member[host] = append(inv.member[host], group)
+	}
+	inv.group(group)
+}
+
+// ancestors retorna o grupo e os grupos que o contêm, dos mais gerais para o
+// mais específico, que é a ordem em que as variáveis são aplicadas.
+func (inv *ansibleInventory) ancestors(name string, seen map[string]bool, out []string) []string {
+	if seen[name] {
+		return out
+	}
+	seen[name] = true
+	for _, parent := range inv.group(name).parents {
+		out = inv.ancestors(parent, seen, out)
+	}
+	return append(out, name)
+}
+
+// config converte os hosts em servidores: os grupos viram tags e as variáveis
+// (dos grupos e do host) preenchem porta, protocolo, healthcheck e replicas.
+func (inv *ansibleInventory) config() (Config, error) {
+	cfg := Config{APIVersion: APIVersionV2, Kind: KindConfig}
+	var errs ValidationErrors
+	for _, host := range inv.hosts {
+		seen := map[string]bool{}
+		groups := inv.ancestors("all", seen, nil)
+		for _, g := range inv.member[host] {
+			groups = inv.ancestors(g, seen, groups)
+		}
+
+		vars := map[string]string{}
+		var tags []string
+		for _, g := range groups {
+			for k, v := range inv.group(g).vars {
+				vars[k] = v
+			}
+			if g != "all" && g != "ungrouped" {
+				tags = append(tags, g)
+			}
+		}
+		for k, v := range inv.vars[host] {
+			vars[k] = v
+		}
+
+		path := "hosts." + host
+		if strings.ContainsAny(host, "[]") {
+			errs.add(path, "import", "intervalos de hosts não são suportados", SeverityError)
+			continue
+		}
+		fields := map[string]string{
+			"name":        host,
+			"host":        firstOf(vars, "ansible_host"),
+			"port":        firstOf(vars, "port", "http_port"),
+			"protocol":    vars["protocol"],
+			"healthcheck": vars["healthcheck"],
+			"replicas":    vars["replicas"],
+		}
+		if fields["host"] == "" {
+			fields["host"] = host
+		}
+		server, err := inventoryServer(fields, tags)
+		if err != nil {
+			errs.add(path, "import", err.Error(), SeverityError)
+			continue
+		}
+		cfg.Servers = append(cfg.Servers, server)
+	}
+
+	if len(errs) > 0 {
+		return cfg, errs
+	}
+	return cfg, nil
+}
+
+// ImportAnsible lê um inventário do Ansible no formato INI ou YAML (detectado
+// pelo conteúdo). Os grupos de cada host viram tags; as variáveis port (ou
+// http_port), protocol, healthcheck, replicas e ansible_host preenchem o servidor.
+// Hosts sem porta são retornados como ValidationErrors.
+func ImportAnsible(filename string, data []byte) (Config, error) {
+	filename = displayName(filename)
+	inv := newAnsibleInventory()
+	var err error
+	if isINIInventory(data) {
+		err = parseAnsibleINI(filename, data, inv)
+	} else {
+		err = parseAnsibleYAML(filename, data, inv)
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	return inv.config()
+}
+
+func isINIInventory(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || line == "---" {
+			continue
+		}
+		// No YAML a primeira linha é um grupo (all:); no INI é uma seção ou um host.
+		return strings.HasPrefix(line, "[") || !strings.HasSuffix(line, ":")
+	}
+	return true
+}
+
+func parseAnsibleINI(filename string, data []byte, inv *ansibleInventory) error {
+	group, section := "ungrouped", "hosts"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") {
+				return fmt.Errorf("%s:%d: seção inválida %q", filename, line, text)
+			}
+			group, section, _ = strings.Cut(text[1:len(text)-1], ":")
+			if section == "" {
+				section = "hosts"
+			}
+			inv.group(group)
+			continue
+		}
+
+		fields, err := splitINIFields(text)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", filename, line, err)
+		}
+		switch section {
+		case "hosts":
+			vars, err := parseINIVars(fields[1:])
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", filename, line, err)
+			}
+			inv.addHost(group, fields[0], vars)
+		case "vars":
+			vars, err := parseINIVars([]string{text})
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", filename, line, err)
+			}
+			for k, v := range vars {
+				inv.group(group).vars[k] = v
+			}
+		case "children":
+			child := inv.group(fields[0])
+			if !slices.Contains(child.parents, group) {
+				child.parents = append(child.parents, group)
+			}
+		default:
+			return fmt.Errorf("%s:%d: seção desconhecida :%s", filename, line, section)
+		}
+	}
+	return scanner.Err()
+}
+
+// splitINIFields separa a linha por espaços, respeitando aspas.
+func splitINIFields(line string) ([]string, error) {
+	var fields []string
+	var b strings.Builder
+	var quote rune
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			b.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ' ' || r == '\t':
+			if b.Len() > 0 {
+				fields = append(fields, b.String())
+				b.Reset()
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("aspas não fechadas")
+	}
+	if b.Len() > 0 {
+		fields = append(fields, b.String())
+	}
+	return fields, nil
+}
+
+func parseINIVars(fields []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, f := range fields {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("variável inválida %q (use nome=valor)", f)
+		}
+		vars[strings.TrimSpace(k)] = strings.Trim(strings.TrimSpace(v), `"'`)
+	}
+	return vars, nil
+}
+
+func parseAnsibleYAML(filename string, data []byte, inv *ansibleInventory) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return yamlDecodeError(filename, err)
+	}
+	doc := unwrapDocument(&root)
+	if doc == nil || doc.Kind != yaml.MappingNode {
+		return errors.New(filename + ": o inventário deve ser um objeto de grupos")
+	}
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if err := parseAnsibleGroup(filename, doc.Content[i].Value, resolveAlias(doc.Content[i+1]), inv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseAnsibleGroup(filename, name string, n *yaml.Node, inv *ansibleInventory) error {
+	inv.group(name)
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return nil
+	}
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s:%d: o grupo %s deve ser um objeto", filename, n.Line, name)
+	}
+
+	if hosts := mappingValue(n, "hosts"); hosts != nil && hosts.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(hosts.Content); i += 2 {
+			vars, err := ansibleVars(filename, resolveAlias(hosts.Content[i+1]))
+			if err != nil {
+				return err
+			}
+			inv.addHost(name, hosts.Content[i].Value, vars)
+		}
+	}
+	if vars := mappingValue(n, "vars"); vars != nil {
+		values, err := ansibleVars(filename, resolveAlias(vars))
+		if err != nil {
+			return err
+		}
+		for k, v := range values {
+			inv.group(name).vars[k] = v
+		}
+	}
+	if children := mappingValue(n, "children"); children != nil && children.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(children.Content); i += 2 {
+			child := children.Content[i].Value
+			if err := parseAnsibleGroup(filename, child, resolveAlias(children.Content[i+1]), inv); err != nil {
+				return err
+			}
+			if g := inv.group(child); !slices.Contains(g.parents, name) {
+				g.parents = append(g.parents, name)
+			}
+		}
+	}
+	return nil
+}
+
+// ansibleVars lê as variáveis escalares de um host ou grupo; as demais não
+// correspondem a nenhum campo do servidor e são ignoradas.
+func ansibleVars(filename string, n *yaml.Node) (map[string]string, error) {
+	vars := map[string]string{}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return vars, nil
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: as variáveis devem ser um objeto", filename, n.Line)
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if v := resolveAlias(n.Content[i+1]); v.Kind == yaml.ScalarNode {
+			vars[n.Content[i].Value] = v.Value
+		}
+	}
+	return vars, nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	data := `Servidor;Endereço;Porta;Grupos
+web-1;10.0.0.1;443;web,prod
+web-2;10.0.0.2;8080;web
+db;10.0.0.3;;
+`
+	cfg, err := ImportCSV("servers.csv", strings.NewReader(data), CSVOptions{
+		Columns: map[string]string{"name": "Servidor", "host": "Endereço", "port": "Porta", "tags": "Grupos"},
+		Comma:   ';',
+	})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "servers.csv:4" {
+		t.Fatalf("Esperado erro na linha 4, obtido %v", err)
+	}
+	expected := []ServerConfig{
+		{Name: "web-1", Host: "10.0.0.1", Port: 443, Protocol: "https", Tags: []string{"web", "prod"}},
+		{Name: "web-2", Host: "10.0.0.2", Port: 8080, Protocol: "http", Tags: []string{"web"}},
+	}
+	if !reflect.DeepEqual(cfg.Servers, expected) {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+
+	if _, err := ImportCSV("servers.csv", strings.NewReader(data), CSVOptions{Columns: map[string]string{"ip": "Endereço"}}); err == nil {
+		t.Error("Esperado erro para campo desconhecido no mapeamento")
+	}
+}
+
+func TestImportAnsibleINI(t *testing.T) {
+	data := `
+bastion ansible_host=1.2.3.4
+
+[web]
+web1 ansible_host=10.0.0.1 http_port=443
+web2 port=8080 protocol=http healthcheck=/health
+
+[web:vars]
+protocol=https
+replicas=2
+
+[prod:children]
+web
+`
+	cfg, err := ImportAnsible("hosts", []byte(data))
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Path != "hosts.bastion" {
+		t.Fatalf("Esperado erro para o host sem porta, obtido %v", err)
+	}
+	expected := []ServerConfig{
+		{Name: "web1", Host: "10.0.0.1", Port: 443, Replicas: 2, Protocol: "https", Tags: []string{"prod", "web"}},
+		{Name: "web2", Host: "web2", Port: 8080, Replicas: 2, Protocol: "http", Healthcheck: "/health", Tags: []string{"prod", "web"}},
+	}
+	if !reflect.DeepEqual(cfg.Servers, expected) {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+}
+
+func TestImportAnsibleYAML(t *testing.T) {
+	data := `
+all:
+  vars:
+    port: 80
+  children:
+    api:
+      hosts:
+        api1:
+          ansible_host: 10.0.1.1
+        api2:
+          port: 443
+`
+	cfg, err := ImportAnsible("inventory.yaml", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ServerConfig{
+		{Name: "api1", Host: "10.0.1.1", Port: 80, Protocol: "http", Tags: []string{"api"}},
+		{Name: "api2", Host: "api2", Port: 443, Protocol: "https", Tags: []string{"api"}},
+	}
+	if !reflect.DeepEqual(cfg.Servers, expected) {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseJSON converte um documento JSON em uma árvore yaml.Node percorrendo os
+// tokens de encoding/json, com a linha e a coluna de cada valor. O texto não
+// passa pelo parser YAML, que rejeita parte do JSON válido (escapes como \/).
+func parseJSON(filename string, data []byte) (*yaml.Node, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line, col := offsetToPosition(data, syntaxErr.Offset)
+			return nil, &DecodeError{File: filename, Line: line, Column: col, Message: syntaxErr.Error()}
+		}
+		return nil, &DecodeError{File: filename, Line: 1, Message: err.Error()}
+	}
+
+	p := &jsonParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+	root, err := p.value()
+	if err != nil {
+		return nil, &DecodeError{File: filename, Line: 1, Message: err.Error()}
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}, Line: 1, Column: 1}, nil
+}
+
+type jsonParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// position retorna a linha e a coluna do próximo token, pulando os espaços e
+// separadores que o json.Decoder ainda não consumiu.
+func (p *jsonParser) position() (int, int) {
+	off := p.dec.InputOffset()
+	for off < int64(len(p.data)) && bytes.IndexByte([]byte(" \t\r\n,:"), p.data[off]) >= 0 {
+		off++
+	}
+	return offsetToPosition(p.data, off)
+}
+
+func (p *jsonParser) value() (*yaml.Node, error) {
+	line, col := p.position()
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line, Column: col}
+			for p.dec.More() {
+				keyLine, keyCol := p.position()
+				key, err := p.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, jsonScalar(key.(string), "!!str", keyLine, keyCol), value)
+			}
+			_, err := p.dec.Token()
+			return n, err
+		}
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line, Column: col}
+		for p.dec.More() {
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, item)
+		}
+		_, err := p.dec.Token()
+		return n, err
+	case string:
+		return jsonScalar(t, "!!str", line, col), nil
+	case json.Number:
+		if _, err := strconv.ParseInt(t.String(), 10, 64); err == nil {
+			return jsonScalar(t.String(), "!!int", line, col), nil
+		}
+		return jsonScalar(t.String(), "!!float", line, col), nil
+	case bool:
+		return jsonScalar(strconv.FormatBool(t), "!!bool", line, col), nil
+	case nil:
+		return jsonScalar("null", "!!null", line, col), nil
+	}
+	return nil, fmt.Errorf("token inesperado %v", tok)
+}
+
+func jsonScalar(value, tag string, line, col int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, Line: line, Column: col}
+}
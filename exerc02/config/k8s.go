@@ -0,0 +1,229 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManagedBy identifica os recursos gerados a partir do arquivo de configuração.
+const ManagedBy = "configparser"
+
+// KubernetesOptions controla a geração dos manifests.
+type KubernetesOptions struct {
+	// Image é a imagem dos containers; {name} é trocado pelo nome do servidor.
+	Image     string
+	Namespace string
+}
+
+// Manifest é um recurso gerado, com o nome de arquivo sugerido.
+type Manifest struct {
+	File   string
+	Object any
+}
+
+type k8sObject struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+	Spec       any               `yaml:"spec,omitempty"`
+}
+
+type k8sMetadata struct {
+	Name      string            `yaml:"name,omitempty"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type k8sDeploymentSpec struct {
+	Replicas int `yaml:"replicas"`
+	Selector struct {
+		MatchLabels map[string]string `yaml:"matchLabels"`
+	} `yaml:"selector"`
+	Template struct {
+		Metadata k8sMetadata `yaml:"metadata"`
+		Spec     struct {
+			Containers []k8sContainer `yaml:"containers"`
+		} `yaml:"spec"`
+	} `yaml:"template"`
+}
+
+type k8sContainer struct {
+	Name           string    `yaml:"name"`
+	Image          string    `yaml:"image"`
+	Ports          []k8sPort `yaml:"ports"`
+	ReadinessProbe *k8sProbe `yaml:"readinessProbe,omitempty"`
+}
+
+type k8sPort struct {
+	Name          string `yaml:"name,omitempty"`
+	ContainerPort int    `yaml:"containerPort,omitempty"`
+	Port          int    `yaml:"port,omitempty"`
+	TargetPort    int    `yaml:"targetPort,omitempty"`
+	Protocol      string `yaml:"protocol,omitempty"`
+}
+
+type k8sProbe struct {
+	HTTPGet       *k8sHTTPGet   `yaml:"httpGet,omitempty"`
+	TCPSocket     *k8sTCPSocket `yaml:"tcpSocket,omitempty"`
+	PeriodSeconds int           `yaml:"periodSeconds"`
+}
+
+type k8sHTTPGet struct {
+	Path   string `yaml:"path"`
+	Port   int    `yaml:"port"`
+	Scheme string `yaml:"scheme"`
+}
+
+type k8sTCPSocket struct {
+	Port int `yaml:"port"`
+}
+
+type k8sServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []k8sPort         `yaml:"ports"`
+}
+
+var dnsLabelRe = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// DNSLabel converte name em um nome aceito pelo Kubernetes (RFC 1123).
+func DNSLabel(name string) string {
+	label := strings.Trim(dnsLabelRe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(label) > 63 {
+		label = strings.TrimRight(label[:63], "-")
+	}
+	return label
+}
+
+// RenderKubernetes gera um Deployment e um Service por servidor e um Secret com
+// os dados do banco de dados. O readiness probe usa Healthcheck e Protocol; sem
+// healthcheck, apenas a porta é verificada.
+func RenderKubernetes(cfg Config, opts KubernetesOptions) ([]Manifest, error) {
+	if opts.Image == "" {
+		opts.Image = "{name}:latest"
+	}
+
+	var manifests []Manifest
+	seen := map[string]string{}
+	for _, s := range cfg.Servers {
+		name := DNSLabel(s.Name)
+		if name == "" {
+			return nil, fmt.Errorf("servidor %q não gera um nome válido no Kubernetes", s.Name)
+		}
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("servidores %q e %q geram o mesmo nome %q", other, s.Name, name)
+		}
+		seen[name] = s.Name
+
+		labels := map[string]string{"app.kubernetes.io/name": name, "app.kubernetes.io/managed-by": ManagedBy}
+		for k, v := range s.Labels {
+			if _, ok := labels[k]; !ok {
+				labels[k] = v
+			}
+		}
+		meta := k8sMetadata{Name: name, Namespace: opts.Namespace, Labels: labels}
+
+		var deployment k8sDeploymentSpec
+		deployment.Replicas = s.Replicas
+		deployment.Selector.MatchLabels = map[string]string{"app.kubernetes.io/name": name}
+		deployment.Template.Metadata = k8sMetadata{Labels: labels}
+		deployment.Template.Spec.Containers = []k8sContainer{{
+			Name:           name,
+			Image:          strings.ReplaceAll(opts.Image, "{name}", name),
+			Ports:          []k8sPort{{Name: portName(s.Protocol), ContainerPort: s.Port}},
+			ReadinessProbe: readinessProbe(s),
+		}}
+
+		manifests = append(manifests,
+			Manifest{File: name + "-deployment.yaml", Object: k8sObject{APIVersion: "apps/v1", Kind: "Deployment", Metadata: meta, Spec: deployment}},
+			Manifest{File: name + "-service.yaml", Object: k8sObject{APIVersion: "v1", Kind: "Service", Metadata: meta, Spec: k8sServiceSpec{
+				Selector: deployment.Selector.MatchLabels,
+				Ports:    []k8sPort{{Name: portName(s.Protocol), Port: s.Port, TargetPort: s.Port, Protocol: "TCP"}},
+			}}},
+		)
+	}
+
+	db := cfg.Database
+	if db != (DatabaseConfig{}) {
+		manifests = append(manifests, Manifest{File: "database-secret.yaml", Object: k8sObject{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Metadata:   k8sMetadata{Name: "database", Namespace: opts.Namespace, Labels: map[string]string{"app.kubernetes.io/managed-by": ManagedBy}},
+			Type:       "Opaque",
+			StringData: map[string]string{"host": db.Host, "port": strconv.Itoa(db.Port), "user": db.User, "password": db.Password},
+		}})
+	}
+	return manifests, nil
+}
+
+func portName(protocol string) string {
+	if protocol == "" {
+		return "http"
+	}
+	return protocol
+}
+
+func readinessProbe(s ServerConfig) *k8sProbe {
+	probe := &k8sProbe{PeriodSeconds: 10}
+	if s.Healthcheck == "" {
+		probe.TCPSocket = &k8sTCPSocket{Port: s.Port}
+		return probe
+	}
+	probe.HTTPGet = &k8sHTTPGet{Path: healthcheckPath(s.Healthcheck), Port: s.Port, Scheme: strings.ToUpper(portName(s.Protocol))}
+	return probe
+}
+
+// EncodeManifests serializa os manifests como um único YAML com vários documentos.
+func EncodeManifests(manifests []Manifest) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, m := range manifests {
+		if err := enc.Encode(m.Object); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteKustomize grava um arquivo por manifest em dir, junto com o
+// kustomization.yaml que os referencia.
+func WriteKustomize(dir string, manifests []Manifest, namespace string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	kustomization := struct {
+		APIVersion string   `yaml:"apiVersion"`
+		Kind       string   `yaml:"kind"`
+		Namespace  string   `yaml:"namespace,omitempty"`
+		Resources  []string `yaml:"resources"`
+	}{APIVersion: "kustomize.config.k8s.io/v1beta1", Kind: "Kustomization", Namespace: namespace}
+
+	for _, m := range manifests {
+		data, err := EncodeManifests([]Manifest{m})
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, m.File), data, 0644); err != nil {
+			return err
+		}
+		kustomization.Resources = append(kustomization.Resources, m.File)
+	}
+
+	data, err := EncodeManifests([]Manifest{{Object: kustomization}})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "kustomization.yaml"), data, 0644)
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderKubernetes(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "Web_App", Host: "localhost", Port: 443, Replicas: 3, Healthcheck: "status/200", Protocol: "https"},
+			{Name: "worker", Host: "localhost", Port: 9000},
+		},
+		Database: DatabaseConfig{Host: "db", Port: 5432, User: "admin", Password: "secret"},
+	}
+
+	manifests, err := RenderKubernetes(cfg, KubernetesOptions{Image: "registry/{name}:1.0", Namespace: "prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 5 {
+		t.Fatalf("Esperado 5 manifests, obtido %d", len(manifests))
+	}
+
+	deployment := manifests[0].Object.(k8sObject)
+	spec := deployment.Spec.(k8sDeploymentSpec)
+	if manifests[0].File != "web-app-deployment.yaml" || deployment.Metadata.Namespace != "prod" {
+		t.Errorf("Deployment inesperado: %s %+v", manifests[0].File, deployment.Metadata)
+	}
+	if spec.Replicas != 3 {
+		t.Errorf("Esperado 3 réplicas, obtido %d", spec.Replicas)
+	}
+	container := spec.Template.Spec.Containers[0]
+	if container.Image != "registry/web-app:1.0" {
+		t.Errorf("Imagem inesperada: %s", container.Image)
+	}
+	probe := container.ReadinessProbe.HTTPGet
+	if probe == nil || probe.Path != "/status/200" || probe.Scheme != "HTTPS" || probe.Port != 443 {
+		t.Errorf("Readiness probe inesperado: %+v", container.ReadinessProbe)
+	}
+
+	worker := manifests[2].Object.(k8sObject).Spec.(k8sDeploymentSpec)
+	if worker.Replicas != 0 || worker.Template.Spec.Containers[0].ReadinessProbe.TCPSocket == nil {
+		t.Errorf("Servidor sem healthcheck deve usar tcpSocket e manter replicas 0: %+v", worker)
+	}
+
+	secret := manifests[4].Object.(k8sObject)
+	if secret.Kind != "Secret" || secret.StringData["port"] != "5432" || secret.StringData["password"] != "secret" {
+		t.Errorf("Secret inesperado: %+v", secret)
+	}
+}
+
+func TestRenderKubernetesDuplicateName(t *testing.T) {
+	cfg := Config{Servers: []ServerConfig{
+		{Name: "web.app", Port: 80},
+		{Name: "web_app", Port: 81},
+	}}
+	if _, err := RenderKubernetes(cfg, KubernetesOptions{}); err == nil {
+		t.Error("Esperado erro para servidores com o mesmo nome no Kubernetes")
+	}
+}
+
+func TestWriteKustomize(t *testing.T) {
+	cfg := Config{Servers: []ServerConfig{{Name: "app", Port: 8080, Healthcheck: "/health"}}}
+	manifests, err := RenderKubernetes(cfg, KubernetesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "k8s")
+	if err := WriteKustomize(dir, manifests, "staging"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"namespace: staging", "- app-deployment.yaml", "- app-service.yaml"} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("kustomization.yaml sem %q:\n%s", expected, data)
+		}
+	}
+	deployment, err := os.ReadFile(filepath.Join(dir, "app-deployment.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(deployment), "image: app:latest") || !strings.Contains(string(deployment), "scheme: HTTP") {
+		t.Errorf("Deployment inesperado:\n%s", deployment)
+	}
+}
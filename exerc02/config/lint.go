@@ -0,0 +1,195 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintConfigFile é o arquivo procurado por padrão para configurar as regras do lint.
+const LintConfigFile = ".configlint.yaml"
+
+// LintConfig define quais regras são executadas e os limites usados por elas.
+type LintConfig struct {
+	Rules    map[string]bool `json:"rules" yaml:"rules"`
+	Replicas struct {
+		Min int `json:"min" yaml:"min"`
+		Max int `json:"max" yaml:"max"`
+	} `json:"replicas" yaml:"replicas"`
+	// MinResponseTime é o menor max_response_time aceito; valores abaixo dele
+	// provavelmente foram escritos em segundos em vez de milissegundos.
+	MinResponseTime int `json:"min_response_time" yaml:"min_response_time"`
+}
+
+// LintRule é uma regra do lint que avalia a configuração como um todo.
+type LintRule struct {
+	Name        string
+	Description string
+	check       func(cfg Config, lc LintConfig) ValidationErrors
+}
+
+// LintRules lista as regras disponíveis, todas habilitadas por padrão.
+var LintRules = []LintRule{
+	{"duplicate-name", "nomes de servidores ou websites repetidos", lintDuplicateName},
+	{"duplicate-host-port", "dois servidores no mesmo host:porta", lintDuplicateHostPort},
+	{"replicas-range", "replicas fora do intervalo configurado", lintReplicasRange},
+	{"healthcheck-path", "healthcheck sem / no início", lintHealthcheckPath},
+	{"protocol", "protocolo diferente de http ou https", lintProtocol},
+	{"response-time-unit", "max_response_time que parece estar em segundos", lintResponseTimeUnit},
+}
+
+// DefaultLintConfig retorna a configuração usada quando não há .configlint.yaml.
+func DefaultLintConfig() LintConfig {
+	lc := LintConfig{Rules: map[string]bool{}, MinResponseTime: 100}
+	lc.Replicas.Min = 1
+	lc.Replicas.Max = 100
+	return lc
+}
+
+// LoadLintConfig lê um .configlint.yaml, aplicando-o sobre DefaultLintConfig.
+func LoadLintConfig(path string) (LintConfig, error) {
+	lc := DefaultLintConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lc, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&lc); err != nil && !errors.Is(err, io.EOF) {
+		return lc, fmt.Errorf("%s: %w", path, err)
+	}
+	for name := range lc.Rules {
+		if lintRule(name) == nil {
+			return lc, fmt.Errorf("%s: regra desconhecida %q", path, name)
+		}
+	}
+	if lc.Replicas.Max < lc.Replicas.Min {
+		return lc, fmt.Errorf("%s: replicas.max (%d) menor que replicas.min (%d)", path, lc.Replicas.Max, lc.Replicas.Min)
+	}
+	return lc, nil
+}
+
+// Enabled indica se a regra deve ser executada; regras não listadas ficam habilitadas.
+func (lc LintConfig) Enabled(rule string) bool {
+	enabled, ok := lc.Rules[rule]
+	return !ok || enabled
+}
+
+// Lint executa as regras habilitadas e retorna os problemas encontrados, usando
+// o nome da regra em ValidationError.Rule.
+func Lint(cfg Config, lc LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	for _, rule := range LintRules {
+		if lc.Enabled(rule.Name) {
+			errs = append(errs, rule.check(cfg, lc)...)
+		}
+	}
+	return errs
+}
+
+func lintRule(name string) *LintRule {
+	for i := range LintRules {
+		if LintRules[i].Name == name {
+			return &LintRules[i]
+		}
+	}
+	return nil
+}
+
+func lintDuplicateName(cfg Config, _ LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	check := func(section string, names []string) {
+		first := map[string]int{}
+		for i, name := range names {
+			if name == "" {
+				continue
+			}
+			if j, ok := first[name]; ok {
+				errs.add(fmt.Sprintf("%s[%d].name", section, i), "duplicate-name",
+					fmt.Sprintf("nome %q já usado em %s[%d]", name, section, j), SeverityError)
+				continue
+			}
+			first[name] = i
+		}
+	}
+
+	servers := make([]string, len(cfg.Servers))
+	for i, s := range cfg.Servers {
+		servers[i] = s.Name
+	}
+	websites := make([]string, len(cfg.Website))
+	for i, w := range cfg.Website {
+		websites[i] = w.Name
+	}
+	check("servers", servers)
+	check("websites", websites)
+	return errs
+}
+
+func lintDuplicateHostPort(cfg Config, _ LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	first := map[string]int{}
+	for i, s := range cfg.Servers {
+		if s.Host == "" || s.Port == 0 {
+			continue
+		}
+		addr := fmt.Sprintf("%s:%d", strings.ToLower(s.Host), s.Port)
+		if j, ok := first[addr]; ok {
+			errs.add(fmt.Sprintf("servers[%d]", i), "duplicate-host-port",
+				fmt.Sprintf("%s já usado por %s", addr, cfg.Servers[j].Name), SeverityWarning)
+			continue
+		}
+		first[addr] = i
+	}
+	return errs
+}
+
+func lintReplicasRange(cfg Config, lc LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	for i, s := range cfg.Servers {
+		if s.Replicas < lc.Replicas.Min || s.Replicas > lc.Replicas.Max {
+			errs.add(fmt.Sprintf("servers[%d].replicas", i), "replicas-range",
+				fmt.Sprintf("replicas %d fora do intervalo %d-%d", s.Replicas, lc.Replicas.Min, lc.Replicas.Max), SeverityWarning)
+		}
+	}
+	return errs
+}
+
+func lintHealthcheckPath(cfg Config, _ LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	for i, s := range cfg.Servers {
+		if s.Healthcheck != "" && !strings.HasPrefix(s.Healthcheck, "/") {
+			errs.add(fmt.Sprintf("servers[%d].healthcheck", i), "healthcheck-path",
+				fmt.Sprintf("healthcheck %q deve começar com /", s.Healthcheck), SeverityError)
+		}
+	}
+	return errs
+}
+
+func lintProtocol(cfg Config, _ LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	for i, s := range cfg.Servers {
+		if s.Protocol != "" && s.Protocol != "http" && s.Protocol != "https" {
+			errs.add(fmt.Sprintf("servers[%d].protocol", i), "protocol",
+				fmt.Sprintf("protocolo %q não suportado (use http ou https)", s.Protocol), SeverityError)
+		}
+	}
+	return errs
+}
+
+func lintResponseTimeUnit(cfg Config, lc LintConfig) ValidationErrors {
+	var errs ValidationErrors
+	for i, w := range cfg.Website {
+		if w.MaxResponseTime > 0 && w.MaxResponseTime < lc.MinResponseTime {
+			errs.add(fmt.Sprintf("websites[%d].max_response_time", i), "response-time-unit",
+				fmt.Sprintf("%d parece estar em segundos; o valor é em milissegundos (%d ms?)", w.MaxResponseTime, w.MaxResponseTime*1000), SeverityWarning)
+		}
+	}
+	return errs
+}
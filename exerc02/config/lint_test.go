@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestLintRules(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "app", Host: "localhost", Port: 8080, Replicas: 3, Healthcheck: "/health", Protocol: "http"},
+			{Name: "app", Host: "LOCALHOST", Port: 8080, Replicas: 500, Healthcheck: "status/200", Protocol: "ftp"},
+		},
+		Website: []WebsiteConfig{
+			{Name: "Example", Url: "https://www.example.com", MaxResponseTime: 2},
+		},
+	}
+
+	found := map[string]string{}
+	for _, e := range Lint(cfg, DefaultLintConfig()) {
+		found[e.Rule] = e.Path
+	}
+	expected := map[string]string{
+		"duplicate-name":      "servers[1].name",
+		"duplicate-host-port": "servers[1]",
+		"replicas-range":      "servers[1].replicas",
+		"healthcheck-path":    "servers[1].healthcheck",
+		"protocol":            "servers[1].protocol",
+		"response-time-unit":  "websites[0].max_response_time",
+	}
+	for rule, path := range expected {
+		if found[rule] != path {
+			t.Errorf("Regra %s: esperado caminho %q, obtido %q", rule, path, found[rule])
+		}
+	}
+	if len(found) != len(expected) {
+		t.Errorf("Regras inesperadas: %v", found)
+	}
+}
+
+func TestLoadLintConfigDisablesRules(t *testing.T) {
+	path := writeTestFile(t, LintConfigFile, "rules:\n  duplicate-host-port: false\nreplicas:\n  max: 5\n")
+
+	lc, err := LoadLintConfig(path)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+	if lc.Enabled("duplicate-host-port") || !lc.Enabled("protocol") {
+		t.Errorf("Regras habilitadas incorretamente: %v", lc.Rules)
+	}
+	if lc.Replicas.Min != 1 || lc.Replicas.Max != 5 || lc.MinResponseTime != 100 {
+		t.Errorf("Os padrões não foram mantidos: %+v", lc)
+	}
+
+	cfg := Config{Servers: []ServerConfig{
+		{Name: "a", Host: "localhost", Port: 80, Replicas: 1},
+		{Name: "b", Host: "localhost", Port: 80, Replicas: 1},
+	}}
+	if errs := Lint(cfg, lc); len(errs) != 0 {
+		t.Errorf("Não eram esperados problemas: %v", errs)
+	}
+
+	bad := writeTestFile(t, "bad.yaml", "rules:\n  inexistente: false\n")
+	if _, err := LoadLintConfig(bad); err == nil {
+		t.Error("Era esperado erro para regra desconhecida")
+	}
+}
@@ -0,0 +1,443 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Defaults são valores aplicados aos campos não informados de cada entrada.
+type Defaults struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+	Website  WebsiteConfig
+}
+
+type loadOptions struct {
+	strict    bool
+	validate  bool
+	overlays  []string
+	env       *Interpolator
+	defaults  []EntryDefaults
+	key       []byte
+	schema    *Schema
+	onWarning func(ValidationError)
+	onOrigin  func(Origin)
+	stdin     io.Reader
+	publicKey ed25519.PublicKey
+	target    *Target
+
+	// allowMissingEnv é aplicado ao Interpolator depois de todas as opções, para
+	// não depender da ordem em relação a WithEnv e WithoutEnv.
+	allowMissingEnv *bool
+}
+
+type LoadOption func(*loadOptions)
+
+// WithStrict controla a rejeição de campos desconhecidos (padrão: true).
+func WithStrict(strict bool) LoadOption {
+	return func(o *loadOptions) { o.strict = strict }
+}
+
+// WithValidation controla a execução de Validate após o parse (padrão: true).
+func WithValidation(validate bool) LoadOption {
+	return func(o *loadOptions) { o.validate = validate }
+}
+
+// WithOverlays mescla os arquivos informados, em ordem, sobre o arquivo base.
+func WithOverlays(paths ...string) LoadOption {
+	return func(o *loadOptions) { o.overlays = append(o.overlays, paths...) }
+}
+
+// WithEnv define o Interpolator usado para expandir ${VAR} e ${file:...}.
+func WithEnv(in Interpolator) LoadOption {
+	return func(o *loadOptions) { o.env = &in }
+}
+
+// WithoutEnv desativa a interpolação de variáveis.
+func WithoutEnv() LoadOption {
+	return func(o *loadOptions) { o.env = nil }
+}
+
+// WithAllowMissingEnv trata variáveis ausentes como aviso em vez de erro.
+func WithAllowMissingEnv(allow bool) LoadOption {
+	return func(o *loadOptions) { o.allowMissingEnv = &allow }
+}
+
+// WithDefaults aplica valores padrão aos campos não informados no arquivo.
+func WithDefaults(d Defaults) LoadOption {
+	return func(o *loadOptions) { o.defaults = d.entries() }
+}
+
+// EntryDefaults são os valores padrão de uma seção do documento: os campos não
+// nulos de Value preenchem os ausentes em cada item de Key, quando List, ou no
+// próprio objeto Key.
+type EntryDefaults struct {
+	Key   string
+	Value any
+	List  bool
+}
+
+// WithEntryDefaults é como WithDefaults para seções arbitrárias, usado pelos
+// formatos carregados com LoadTarget.
+func WithEntryDefaults(entries ...EntryDefaults) LoadOption {
+	return func(o *loadOptions) { o.defaults = entries }
+}
+
+func (d Defaults) entries() []EntryDefaults {
+	return []EntryDefaults{
+		{Key: "servers", Value: d.Server, List: true},
+		{Key: "database", Value: d.Database},
+		{Key: "websites", Value: d.Website, List: true},
+	}
+}
+
+// WithKey define a chave usada para decifrar valores ENC[...].
+func WithKey(key []byte) LoadOption {
+	return func(o *loadOptions) { o.key = key }
+}
+
+// WithSchema valida o documento mesclado contra um JSON Schema.
+func WithSchema(s *Schema) LoadOption {
+	return func(o *loadOptions) { o.schema = s }
+}
+
+// WithStdin troca a origem lida quando o caminho é StdinPath (padrão: os.Stdin).
+func WithStdin(r io.Reader) LoadOption {
+	return func(o *loadOptions) { o.stdin = r }
+}
+
+// WithWarnings recebe os avisos de validação de uma configuração carregada com sucesso.
+func WithWarnings(fn func(ValidationError)) LoadOption {
+	return func(o *loadOptions) { o.onWarning = fn }
+}
+
+// WithOrigins recebe o valor efetivo e a origem (a entrada, uma camada de
+// defaults ou WithDefaults) de cada campo dos servidores e websites carregados.
+func WithOrigins(fn func(Origin)) LoadOption {
+	return func(o *loadOptions) { o.onOrigin = fn }
+}
+
+// WithPublicKey exige que cada arquivo lido (inclusive os overlays) tenha uma
+// assinatura válida para key; o conteúdo verificado é o mesmo que é decodificado.
+func WithPublicKey(key ed25519.PublicKey) LoadOption {
+	return func(o *loadOptions) { o.publicKey = key }
+}
+
+// StdinPath é o caminho que faz Load ler a configuração da entrada padrão.
+const StdinPath = "-"
+
+// Load lê o arquivo de configuração (e os overlays), decifra, interpola, decodifica
+// e valida. Os documentos de um fluxo YAML (---) são mesclados em ordem, como
+// overlays. Erros de leitura e parse são DecodeError/DecodeErrors; problemas de
+// validação são retornados como ValidationErrors.
+func Load(path string, opts ...LoadOption) (*Config, error) {
+	return newLoadOptions(opts).load(path)
+}
+
+// LoadAll é como Load, mas trata cada documento do fluxo YAML de path como uma
+// configuração independente; os overlays são aplicados sobre cada uma delas. Os
+// problemas de validação têm o caminho prefixado por documents[i].
+func LoadAll(path string, opts ...LoadOption) ([]Config, error) {
+	o := newLoadOptions(opts)
+
+	docs, err := o.loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var overlay *yaml.Node
+	var overlayProblems ValidationErrors
+	for _, p := range o.overlays {
+		overlayDocs, err := o.loadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range overlayDocs {
+			overlayProblems = append(overlayProblems, doc.problems...)
+			overlay = MergeNodes(overlay, doc.root)
+		}
+	}
+
+	configs := make([]Config, 0, len(docs))
+	var problems ValidationErrors
+	for i, doc := range docs {
+		name := fmt.Sprintf("%s[%d]", displayName(path), i)
+		cfg, err := o.finish(name, MergeNodes(doc.root, overlay), append(doc.problems, overlayProblems...))
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				e.Path = fmt.Sprintf("documents[%d].%s", i, e.Path)
+				problems = append(problems, e)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, *cfg)
+	}
+	if problems.HasErrors() {
+		return nil, problems
+	}
+	return configs, nil
+}
+
+// Target descreve uma configuração em outro formato (a dos demais exercícios),
+// carregada por LoadTarget com a mesma leitura, interpolação e decodificação de Load.
+type Target struct {
+	// Version é a única versão aceita no cabeçalho apiVersion/kind, que é opcional.
+	Version Version
+	// Out é um ponteiro para a struct que recebe o documento.
+	Out any
+	// Validate confere Out depois da decodificação.
+	Validate func() ValidationErrors
+}
+
+// LoadTarget é como Load, mas decodifica em t.Out e valida com t.Validate. A
+// seção defaults do documento e a migração de versões são exclusivas de Config.
+func LoadTarget(path string, t Target, opts ...LoadOption) error {
+	o := newLoadOptions(opts)
+	o.target = &t
+	_, err := o.load(path)
+	return err
+}
+
+func (o *loadOptions) load(path string) (*Config, error) {
+	paths := append([]string{path}, o.overlays...)
+	var merged *yaml.Node
+	var problems ValidationErrors
+	for _, p := range paths {
+		docs, err := o.loadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			problems = append(problems, doc.problems...)
+			merged = MergeNodes(merged, doc.root)
+		}
+	}
+	return o.finish(displayName(paths...), merged, problems)
+}
+
+func newLoadOptions(opts []LoadOption) *loadOptions {
+	in := NewInterpolator(false)
+	o := &loadOptions{strict: true, validate: true, env: &in, stdin: os.Stdin}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.env != nil && o.allowMissingEnv != nil {
+		o.env.AllowMissing = *o.allowMissingEnv
+	}
+	return o
+}
+
+// finish aplica padrões, schema, decodificação e validação à árvore já mesclada.
+func (o *loadOptions) finish(name string, merged *yaml.Node, problems ValidationErrors) (*Config, error) {
+	if merged == nil {
+		merged = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	var sources map[string]string
+	if o.target == nil {
+		merged, sources = resolveDefaults(merged)
+	}
+	merged = applyEntryDefaults(merged, o.defaults)
+
+	if o.schema != nil {
+		var doc any
+		if err := merged.Decode(&doc); err != nil {
+			return nil, err
+		}
+		if errs := o.schema.Validate(doc); errs.HasErrors() {
+			return nil, errs
+		}
+	}
+
+	var cfg Config
+	out, validate := any(&cfg), func() ValidationErrors { return Validate(cfg) }
+	if o.target != nil {
+		out, validate = o.target.Out, o.target.Validate
+	}
+	if err := decodeNode(name, merged, out, o.strict); err != nil {
+		return nil, err
+	}
+
+	// Os problemas da leitura (variáveis ausentes, por exemplo) valem mesmo sem validação.
+	if o.validate && validate != nil {
+		problems = append(problems, validate()...)
+	}
+	if problems.HasErrors() {
+		return nil, problems
+	}
+	if o.onOrigin != nil && o.target == nil {
+		for _, origin := range origins(merged, sources) {
+			o.onOrigin(origin)
+		}
+	}
+	if o.onWarning != nil {
+		for _, w := range problems {
+			o.onWarning(w)
+		}
+	}
+	return &cfg, nil
+}
+
+type loadedDocument struct {
+	root     *yaml.Node
+	problems ValidationErrors
+}
+
+func (o *loadOptions) loadFile(path string) ([]loadedDocument, error) {
+	var data []byte
+	var err error
+	if path == StdinPath {
+		data, err = io.ReadAll(o.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o arquivo: %w", err)
+	}
+	if o.publicKey != nil {
+		if path == StdinPath {
+			return nil, errors.New("a entrada padrão não pode ter a assinatura conferida")
+		}
+		if err := VerifyData(path, data, o.publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	name := displayName(path)
+	roots, err := ParseDocuments(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]loadedDocument, len(roots))
+	for i, root := range roots {
+		v, declared, err := DeclaredVersion(root)
+		if err != nil {
+			return nil, &DecodeError{File: name, Line: 1, Message: err.Error()}
+		}
+		if o.target != nil {
+			if declared && (v.APIVersion != o.target.Version.APIVersion || v.Kind != o.target.Version.Kind) {
+				return nil, &DecodeError{File: name, Line: 1, Message: fmt.Sprintf("formato %s não suportado (esperado %s)", v, o.target.Version)}
+			}
+		} else if declared && v.Kind != KindConfig {
+			return nil, &DecodeError{File: name, Line: 1, Message: fmt.Sprintf("kind %s não é suportado (esperado %s)", v.Kind, KindConfig)}
+		} else if latest, _ := LatestVersion(KindConfig); declared && v.APIVersion != latest.APIVersion {
+			// Versões antigas são atualizadas em memória; migrate atualiza o arquivo.
+			Migrate(root)
+			docs[i].problems.add("apiVersion", "api-version",
+				fmt.Sprintf("arquivo em %s; execute migrate para atualizar para %s", v.APIVersion, latest.APIVersion), SeverityWarning)
+		}
+
+		if HasEncryptedValues(root) {
+			if o.key == nil {
+				return nil, errors.New(name + ": o arquivo contém valores cifrados e nenhuma chave foi informada")
+			}
+			if _, err := DecryptNode(name, root, o.key); err != nil {
+				return nil, err
+			}
+		}
+
+		if o.env != nil {
+			docs[i].problems = append(docs[i].problems, o.env.ExpandNode(root)...)
+		}
+
+		out := any(&Config{})
+		if o.target != nil {
+			out = reflect.New(reflect.TypeOf(o.target.Out).Elem()).Interface()
+		}
+		if err := decodeNode(name, root, out, o.strict); err != nil {
+			return nil, err
+		}
+		docs[i].root = root
+	}
+	return docs, nil
+}
+
+// displayName retorna os nomes usados nas mensagens de erro, trocando - por <stdin>.
+func displayName(paths ...string) string {
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = p
+		if p == StdinPath {
+			names[i] = "<stdin>"
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// ApplyDefaults preenche, em cada servidor, website e no banco de dados, os campos
+// ausentes da árvore com os valores não nulos de d.
+func ApplyDefaults(root *yaml.Node, d Defaults) *yaml.Node {
+	return applyEntryDefaults(root, d.entries())
+}
+
+func applyEntryDefaults(root *yaml.Node, entries []EntryDefaults) *yaml.Node {
+	if len(entries) == 0 {
+		return root
+	}
+	root = unwrapDocument(root)
+	merged := *root
+	merged.Content = append([]*yaml.Node(nil), root.Content...)
+
+	apply := func(key string, defaults *yaml.Node, sequence bool) {
+		if len(defaults.Content) == 0 {
+			return
+		}
+		idx := mappingIndex(&merged, key)
+		if idx < 0 {
+			if sequence {
+				return
+			}
+			merged.Content = append(merged.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, defaults)
+			return
+		}
+		value := merged.Content[idx+1]
+		if !sequence {
+			merged.Content[idx+1] = MergeNodes(defaults, value)
+			return
+		}
+		if value.Kind != yaml.SequenceNode {
+			return
+		}
+		seq := *value
+		seq.Content = make([]*yaml.Node, len(value.Content))
+		for i, item := range value.Content {
+			seq.Content[i] = MergeNodes(defaults, item)
+		}
+		merged.Content[idx+1] = &seq
+	}
+
+	for _, e := range entries {
+		apply(e.Key, structNode(e.Value), e.List)
+	}
+	return &merged
+}
+
+// structNode converte os campos não nulos de v em um objeto yaml.Node.
+func structNode(v any) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	rv := reflect.ValueOf(v)
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Field(i)
+		name := strings.Split(rv.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" || field.IsZero() {
+			continue
+		}
+		var value yaml.Node
+		if err := value.Encode(field.Interface()); err != nil {
+			continue
+		}
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &value)
+	}
+	return n
+}
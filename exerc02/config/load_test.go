@@ -0,0 +1,218 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadExampleConfig(t *testing.T) {
+	cfg, err := Load("../example_config.yaml", WithEnv(Interpolator{
+		LookupEnv:    func(string) (string, bool) { return "", false },
+		ReadFile:     os.ReadFile,
+		AllowMissing: false,
+	}))
+	if err != nil {
+		t.Fatalf("Erro ao carregar o exemplo: %v", err)
+	}
+	if len(cfg.Servers) != 15 || len(cfg.Website) != 3 {
+		t.Errorf("Quantidade inesperada de entradas: %d servidores, %d websites", len(cfg.Servers), len(cfg.Website))
+	}
+	if cfg.Database.Password != "secret" {
+		t.Errorf("Valor padrão da senha não aplicado: %q", cfg.Database.Password)
+	}
+}
+
+func TestLoadReturnsValidationErrors(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", "servers:\n  - name: app\n    host: localhost\ndatabase:\n  host: localhost\n")
+
+	_, err := Load(path)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || !errs.HasErrors() {
+		t.Fatalf("Esperado ValidationErrors, obtido %v", err)
+	}
+
+	cfg, err := Load(path, WithValidation(false))
+	if err != nil || cfg.Servers[0].Name != "app" {
+		t.Errorf("Sem validação a configuração deveria ser carregada: %v", err)
+	}
+}
+
+func TestLoadMissingEnvWithoutValidation(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", "database:\n  host: ${CONFIGPARSER_TEST_UNSET}\n")
+	env := WithEnv(Interpolator{LookupEnv: func(string) (string, bool) { return "", false }})
+
+	var errs ValidationErrors
+	if _, err := Load(path, env, WithValidation(false)); !errors.As(err, &errs) || errs[0].Rule != "env" {
+		t.Fatalf("Variável ausente deveria falhar mesmo sem validação: %v", err)
+	}
+	// WithAllowMissingEnv vale independentemente da posição em relação a WithEnv.
+	if _, err := Load(path, WithAllowMissingEnv(true), env, WithValidation(false)); err != nil {
+		t.Errorf("Com WithAllowMissingEnv a variável ausente deveria ser só um aviso: %v", err)
+	}
+}
+
+func TestLoadStrictness(t *testing.T) {
+	path := writeTestFile(t, "config.yaml", "database:\n  host: localhost\n  port: 5432\n  user: admin\n  extra: true\n")
+
+	var decodeErrs DecodeErrors
+	if _, err := Load(path); !errors.As(err, &decodeErrs) {
+		t.Fatalf("Campo desconhecido deveria falhar em modo estrito: %v", err)
+	}
+	if _, err := Load(path, WithStrict(false)); err != nil {
+		t.Errorf("Modo não estrito deveria ignorar campos desconhecidos: %v", err)
+	}
+}
+
+func TestLoadOverlaysAndDefaults(t *testing.T) {
+	base := writeTestFile(t, "base.yaml", `servers:
+  - name: app
+    host: app.local
+  - name: api
+    host: api.local
+    port: 9090
+database:
+  host: localhost
+  user: admin
+`)
+	overlay := writeTestFile(t, "prod.yaml", "servers:\n  - name: app\n    replicas: 5\n")
+
+	var warnings []ValidationError
+	cfg, err := Load(base,
+		WithOverlays(overlay),
+		WithDefaults(Defaults{
+			Server:   ServerConfig{Port: 8080, Replicas: 1, Protocol: "http"},
+			Database: DatabaseConfig{Port: 5432},
+		}),
+		WithWarnings(func(w ValidationError) { warnings = append(warnings, w) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, api := cfg.Servers[0], cfg.Servers[1]
+	if app.Port != 8080 || app.Replicas != 5 || app.Protocol != "http" {
+		t.Errorf("app com valores inesperados: %+v", app)
+	}
+	if api.Port != 9090 || api.Replicas != 1 {
+		t.Errorf("Valores explícitos devem prevalecer sobre os padrões: %+v", api)
+	}
+	if cfg.Database.Port != 5432 {
+		t.Errorf("Padrão do banco não aplicado: %+v", cfg.Database)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Avisos inesperados: %v", warnings)
+	}
+}
+
+const multiDocument = `servers:
+  - name: app
+    host: app.local
+    port: 80
+    protocol: http
+database:
+  host: localhost
+  port: 5432
+  user: admin
+---
+servers:
+  - name: app
+    replicas: 2
+  - name: api
+    host: api.local
+    port: 81
+    protocol: http
+database:
+  host: db.local
+  port: 5432
+  user: admin
+`
+
+func TestLoadMultiDocumentFromStdin(t *testing.T) {
+	cfg, err := Load(StdinPath, WithStdin(strings.NewReader(multiDocument)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Servers) != 2 || cfg.Servers[0].Replicas != 2 || cfg.Servers[0].Host != "app.local" {
+		t.Errorf("Documentos não foram mesclados em ordem: %+v", cfg.Servers)
+	}
+	if cfg.Database.Host != "db.local" {
+		t.Errorf("O último documento deveria prevalecer: %+v", cfg.Database)
+	}
+}
+
+func TestLoadAllDocuments(t *testing.T) {
+	path := writeTestFile(t, "stream.yaml", multiDocument)
+
+	_, err := LoadAll(path)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || errs[0].Path != "documents[1].servers[0].host" {
+		t.Fatalf("Esperado erro no segundo documento, obtido %v", err)
+	}
+
+	cfgs, err := LoadAll(path, WithValidation(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfgs) != 2 || len(cfgs[0].Servers) != 1 || cfgs[1].Servers[1].Name != "api" {
+		t.Errorf("Documentos deveriam ser processados separadamente: %+v", cfgs)
+	}
+}
+
+func TestLoadTarget(t *testing.T) {
+	type deploy struct {
+		Name    string   `yaml:"name"`
+		Image   string   `yaml:"image"`
+		Restart string   `yaml:"restart"`
+		Env     []string `yaml:"env"`
+	}
+	var cfg struct {
+		APIVersion string   `yaml:"apiVersion"`
+		Kind       string   `yaml:"kind"`
+		Servers    []deploy `yaml:"servers"`
+	}
+	target := Target{
+		Version: Version{APIVersion: APIVersionV1, Kind: KindDeploy},
+		Out:     &cfg,
+		Validate: func() ValidationErrors {
+			var errs ValidationErrors
+			for i, s := range cfg.Servers {
+				if s.Image == "" {
+					errs.add(fmt.Sprintf("servers[%d].image", i), "required", "campo obrigatório ausente", SeverityError)
+				}
+			}
+			return errs
+		},
+	}
+	t.Setenv("WEB_IMAGE", "nginx")
+	path := writeTestFile(t, "deploy.yaml", "apiVersion: configparser/v1\nkind: DeployConfig\nservers:\n  - name: web\n    image: ${WEB_IMAGE}\n  - name: api\n")
+
+	if err := LoadTarget(path, target, WithEntryDefaults(EntryDefaults{Key: "servers", Value: deploy{Restart: "always"}, List: true})); err == nil {
+		t.Error("Esperado erro de validação no servidor sem image")
+	}
+	err := LoadTarget(path, target, WithEntryDefaults(EntryDefaults{Key: "servers", Value: deploy{Image: "busybox", Restart: "always"}, List: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Servers[0].Image != "nginx" || cfg.Servers[1].Image != "busybox" || cfg.Servers[1].Restart != "always" {
+		t.Errorf("Servidores inesperados: %+v", cfg.Servers)
+	}
+
+	other := writeTestFile(t, "config.yaml", "apiVersion: configparser/v2\nkind: Config\nservers: []\n")
+	var decodeErr *DecodeError
+	if err := LoadTarget(other, target); !errors.As(err, &decodeErr) {
+		t.Errorf("Esperado erro de versão para outro kind, obtido %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// MergeKey é o campo usado para casar itens de listas entre a base e os overlays.
+const MergeKey = "name"
+
+// MergeNodes aplica overlay sobre base e retorna a árvore resultante. Objetos são
+// mesclados recursivamente; listas de objetos com o campo name (servers, websites)
+// são mescladas item a item pelo nome; os demais valores do overlay substituem a base.
+func MergeNodes(base, overlay *yaml.Node) *yaml.Node {
+	base, overlay = unwrapDocument(base), unwrapDocument(overlay)
+	if base == nil {
+		return overlay
+	}
+	if overlay == nil || (overlay.Kind == yaml.ScalarNode && overlay.ShortTag() == "!!null") {
+		return base
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && overlay.Kind == yaml.MappingNode:
+		merged := *base
+		merged.Content = append([]*yaml.Node(nil), base.Content...)
+		for i := 0; i+1 < len(overlay.Content); i += 2 {
+			key, value := overlay.Content[i], overlay.Content[i+1]
+			if idx := mappingIndex(&merged, key.Value); idx >= 0 {
+				merged.Content[idx+1] = MergeNodes(merged.Content[idx+1], value)
+			} else {
+				merged.Content = append(merged.Content, key, value)
+			}
+		}
+		return &merged
+	case base.Kind == yaml.SequenceNode && overlay.Kind == yaml.SequenceNode && keyedSequence(base) && keyedSequence(overlay):
+		merged := *base
+		merged.Content = append([]*yaml.Node(nil), base.Content...)
+		for _, item := range overlay.Content {
+			name := mappingValue(item, MergeKey).Value
+			found := false
+			for i, existing := range merged.Content {
+				if mappingValue(existing, MergeKey).Value == name {
+					merged.Content[i] = MergeNodes(existing, item)
+					found = true
+					break
+				}
+			}
+			if !found {
+				merged.Content = append(merged.Content, item)
+			}
+		}
+		return &merged
+	}
+	return overlay
+}
+
+func unwrapDocument(n *yaml.Node) *yaml.Node {
+	if n != nil && n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return n.Content[0]
+	}
+	return n
+}
+
+func mappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	if idx := mappingIndex(n, key); idx >= 0 {
+		return n.Content[idx+1]
+	}
+	return nil
+}
+
+func keyedSequence(n *yaml.Node) bool {
+	for _, item := range n.Content {
+		v := mappingValue(item, MergeKey)
+		if v == nil || v.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
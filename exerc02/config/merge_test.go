@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func parseTestNode(t *testing.T, doc string) *yaml.Node {
+	t.Helper()
+	root, err := ParseNode("test.yaml", []byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestMergeNodesByName(t *testing.T) {
+	base := parseTestNode(t, `servers:
+  - name: app
+    host: app.local
+    port: 8080
+    replicas: 3
+  - name: api
+    host: api.local
+    port: 9090
+database:
+  host: localhost
+  port: 5432
+  user: admin
+`)
+	overlay := parseTestNode(t, `servers:
+  - name: api
+    replicas: 5
+  - name: worker
+    host: worker.local
+    port: 7070
+database:
+  host: prod-db
+`)
+
+	var cfg Config
+	if err := DecodeNode("merged", MergeNodes(base, overlay), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.Servers) != 3 {
+		t.Fatalf("Esperados 3 servidores, obtidos %d", len(cfg.Servers))
+	}
+	if cfg.Servers[0].Replicas != 3 {
+		t.Errorf("Servidor não presente no overlay deve ser preservado: %+v", cfg.Servers[0])
+	}
+	api := cfg.Servers[1]
+	if api.Host != "api.local" || api.Port != 9090 || api.Replicas != 5 {
+		t.Errorf("Servidor api mesclado incorretamente: %+v", api)
+	}
+	if cfg.Servers[2].Name != "worker" {
+		t.Errorf("Novo servidor deveria ser adicionado ao final: %+v", cfg.Servers[2])
+	}
+	if cfg.Database.Host != "prod-db" || cfg.Database.User != "admin" {
+		t.Errorf("Banco de dados mesclado incorretamente: %+v", cfg.Database)
+	}
+}
+
+func TestMergeNodesDoesNotMutateBase(t *testing.T) {
+	base := parseTestNode(t, "database:\n  host: localhost\n")
+	overlay := parseTestNode(t, "database:\n  user: admin\n")
+	MergeNodes(base, overlay)
+
+	var cfg Config
+	if err := DecodeNode("base", base, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.User != "" {
+		t.Errorf("A base não deveria ser alterada: %+v", cfg.Database)
+	}
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// HealthState é o estado de um servidor acompanhado por Monitor.
+type HealthState string
+
+const (
+	StateUnknown  HealthState = "unknown"
+	StateUp       HealthState = "up"
+	StateDegraded HealthState = "degraded"
+	StateDown     HealthState = "down"
+)
+
+// Eventos emitidos por Monitor.
+const (
+	EventTransition EventType = "transition"
+	EventHeartbeat  EventType = "heartbeat"
+)
+
+// CheckResult é o resultado de uma verificação de saúde de um servidor.
+type CheckResult struct {
+	Healthy    bool
+	StatusCode int
+	Error      string
+}
+
+// HealthChecker verifica um servidor; deve respeitar o cancelamento de ctx.
+type HealthChecker func(ctx context.Context, server ServerConfig) CheckResult
+
+// ServerStatus é o estado atual de um servidor, enviado nos heartbeats.
+type ServerStatus struct {
+	Name      string      `json:"name" yaml:"name"`
+	State     HealthState `json:"state" yaml:"state"`
+	Failures  int         `json:"failures" yaml:"failures"`
+	Since     time.Time   `json:"since" yaml:"since"`
+	LastCheck time.Time   `json:"last_check,omitzero" yaml:"last_check,omitempty"`
+}
+
+// MonitorEvent é uma mudança de estado de um servidor (EventTransition) ou o
+// resumo periódico de todos eles (EventHeartbeat).
+type MonitorEvent struct {
+	Time       time.Time      `json:"time" yaml:"time"`
+	Type       EventType      `json:"type" yaml:"type"`
+	Server     string         `json:"server,omitempty" yaml:"server,omitempty"`
+	From       HealthState    `json:"from,omitempty" yaml:"from,omitempty"`
+	To         HealthState    `json:"to,omitempty" yaml:"to,omitempty"`
+	StatusCode int            `json:"status_code,omitempty" yaml:"status_code,omitempty"`
+	Error      string         `json:"error,omitempty" yaml:"error,omitempty"`
+	Servers    []ServerStatus `json:"servers,omitempty" yaml:"servers,omitempty"`
+}
+
+// MonitorOptions controla Monitor.
+type MonitorOptions struct {
+	// Interval é o intervalo entre verificações dos servidores sem interval
+	// próprio (padrão: 30s).
+	Interval time.Duration
+	// Heartbeat é o intervalo entre os resumos de estado; zero desliga.
+	Heartbeat time.Duration
+	// Failures é o número de falhas seguidas até o servidor ficar down (padrão: 3).
+	Failures int
+	Checker  HealthChecker
+}
+
+// Monitor verifica cada servidor no seu intervalo até ctx ser cancelado. Todo
+// servidor começa unknown; uma verificação bem-sucedida o leva a up, uma falha a
+// degraded e Failures falhas seguidas a down. Só as mudanças de estado geram
+// eventos, além dos heartbeats. O canal é fechado quando ctx é cancelado.
+func Monitor(ctx context.Context, servers []ServerConfig, opts MonitorOptions) <-chan MonitorEvent {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.Failures < 1 {
+		opts.Failures = 3
+	}
+	m := &monitor{opts: opts, events: make(chan MonitorEvent), status: make([]ServerStatus, len(servers))}
+	now := time.Now()
+	for i, s := range servers {
+		m.status[i] = ServerStatus{Name: s.Name, State: StateUnknown, Since: now}
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.run(ctx, i, s)
+		}()
+	}
+	if opts.Heartbeat > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.heartbeat(ctx)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(m.events)
+	}()
+	return m.events
+}
+
+// ServerInterval retorna o interval do servidor, ou def se ele não tiver um válido.
+func ServerInterval(s ServerConfig, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s.Interval); err == nil && d > 0 {
+		return d
+	}
+	return def
+}
+
+type monitor struct {
+	opts   MonitorOptions
+	events chan MonitorEvent
+
+	mu     sync.Mutex
+	status []ServerStatus
+}
+
+func (m *monitor) run(ctx context.Context, i int, s ServerConfig) {
+	ticker := time.NewTicker(ServerInterval(s, m.opts.Interval))
+	defer ticker.Stop()
+	for {
+		result := m.opts.Checker(ctx, s)
+		if ctx.Err() != nil {
+			return
+		}
+		if ev, changed := m.record(i, result); changed && !m.send(ctx, ev) {
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// record aplica o resultado ao estado do servidor e retorna o evento da
+// transição, se houve uma.
+func (m *monitor) record(i int, r CheckResult) (MonitorEvent, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := &m.status[i]
+	now := time.Now()
+	st.LastCheck = now
+	if r.Healthy {
+		st.Failures = 0
+	} else {
+		st.Failures++
+	}
+	next := nextState(st.Failures, m.opts.Failures, r.Healthy)
+	if next == st.State {
+		return MonitorEvent{}, false
+	}
+	ev := MonitorEvent{Time: now, Type: EventTransition, Server: st.Name, From: st.State, To: next, StatusCode: r.StatusCode, Error: r.Error}
+	st.State, st.Since = next, now
+	return ev, true
+}
+
+func nextState(failures, threshold int, healthy bool) HealthState {
+	switch {
+	case healthy:
+		return StateUp
+	case failures >= threshold:
+		return StateDown
+	}
+	return StateDegraded
+}
+
+func (m *monitor) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(m.opts.Heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		m.mu.Lock()
+		servers := slices.Clone(m.status)
+		m.mu.Unlock()
+		if !m.send(ctx, MonitorEvent{Time: time.Now(), Type: EventHeartbeat, Servers: servers}) {
+			return
+		}
+	}
+}
+
+func (m *monitor) send(ctx context.Context, ev MonitorEvent) bool {
+	select {
+	case m.events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonitorTransitions(t *testing.T) {
+	// Cada verificação consome o próximo resultado; depois do fim, o servidor fica up.
+	var mu sync.Mutex
+	script := []bool{true, false, false, false, true}
+	checker := func(ctx context.Context, s ServerConfig) CheckResult {
+		mu.Lock()
+		defer mu.Unlock()
+		healthy := true
+		if len(script) > 0 {
+			healthy, script = script[0], script[1:]
+		}
+		if !healthy {
+			return CheckResult{StatusCode: 503, Error: "status 503"}
+		}
+		return CheckResult{Healthy: true, StatusCode: 200}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	servers := []ServerConfig{{Name: "web", Interval: "1ms"}}
+	events := Monitor(ctx, servers, MonitorOptions{Interval: time.Hour, Checker: checker})
+
+	want := []struct{ from, to HealthState }{
+		{StateUnknown, StateUp},
+		{StateUp, StateDegraded},
+		{StateDegraded, StateDown},
+		{StateDown, StateUp},
+	}
+	for i, w := range want {
+		ev, ok := <-events
+		if !ok {
+			t.Fatalf("Canal fechado antes da transição %d", i)
+		}
+		if ev.Type != EventTransition || ev.Server != "web" || ev.From != w.from || ev.To != w.to {
+			t.Errorf("Transição %d: obtido %s %s→%s, esperado %s→%s", i, ev.Type, ev.From, ev.To, w.from, w.to)
+		}
+	}
+
+	// Enquanto o servidor continua up, nenhuma transição nova é emitida.
+	select {
+	case ev := <-events:
+		t.Errorf("Evento inesperado: %+v", ev)
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	for range events {
+	}
+}
+
+func TestMonitorHeartbeat(t *testing.T) {
+	checker := func(ctx context.Context, s ServerConfig) CheckResult {
+		return CheckResult{Error: "connection refused"}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	servers := []ServerConfig{{Name: "a"}, {Name: "b"}}
+	events := Monitor(ctx, servers, MonitorOptions{Interval: time.Hour, Heartbeat: time.Millisecond, Checker: checker})
+
+	for ev := range events {
+		if ev.Type != EventHeartbeat {
+			continue
+		}
+		if len(ev.Servers) != 2 || ev.Servers[0].Name != "a" {
+			t.Fatalf("Heartbeat inesperado: %+v", ev.Servers)
+		}
+		if ev.Servers[0].State == StateDegraded && ev.Servers[1].State == StateDegraded {
+			cancel()
+		}
+	}
+}
+
+func TestServerInterval(t *testing.T) {
+	if d := ServerInterval(ServerConfig{Interval: "10s"}, time.Minute); d != 10*time.Second {
+		t.Errorf("Esperado 10s, obtido %s", d)
+	}
+	for _, interval := range []string{"", "abc", "-1s"} {
+		if d := ServerInterval(ServerConfig{Interval: interval}, time.Minute); d != time.Minute {
+			t.Errorf("%q: esperado o padrão, obtido %s", interval, d)
+		}
+	}
+	errs := Validate(Config{Servers: []ServerConfig{{Name: "a", Host: "h", Port: 80, Protocol: "http", Interval: "0s"}}, Database: DatabaseConfig{Host: "db", Port: 5432, User: "u"}})
+	if len(errs) != 1 || errs[0].Path != "servers[0].interval" {
+		t.Errorf("Esperado erro em servers[0].interval, obtido %v", errs)
+	}
+}
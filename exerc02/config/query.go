@@ -0,0 +1,250 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pathSegment é um passo de um caminho como servers[name=httpbin-1].port: um campo
+// de objeto (key), uma posição em lista (index) ou um seletor [field=value].
+type pathSegment struct {
+	key   string
+	index int
+	field string
+	value string
+}
+
+func (s pathSegment) String() string {
+	switch {
+	case s.key != "":
+		return s.key
+	case s.field != "":
+		return fmt.Sprintf("[%s=%s]", s.field, s.value)
+	}
+	return fmt.Sprintf("[%d]", s.index)
+}
+
+func parsePath(expr string) ([]pathSegment, error) {
+	var segs []pathSegment
+	for i := 0; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			if i == 0 || i+1 == len(expr) || expr[i+1] == '.' || expr[i+1] == '[' {
+				return nil, fmt.Errorf("caminho inválido %q: ponto na posição %d", expr, i+1)
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("caminho inválido %q: ] ausente", expr)
+			}
+			inner := expr[i+1 : i+end]
+			if field, value, ok := strings.Cut(inner, "="); ok {
+				if field == "" {
+					return nil, fmt.Errorf("caminho inválido %q: seletor sem campo", expr)
+				}
+				segs = append(segs, pathSegment{field: field, value: strings.Trim(value, `"'`), index: -1})
+			} else {
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("caminho inválido %q: índice %q", expr, inner)
+				}
+				segs = append(segs, pathSegment{index: n})
+			}
+			i += end + 1
+		default:
+			j := i
+			for j < len(expr) && expr[j] != '.' && expr[j] != '[' {
+				j++
+			}
+			segs = append(segs, pathSegment{key: expr[i:j], index: -1})
+			i = j
+		}
+	}
+	if len(segs) == 0 {
+		return nil, errors.New("caminho vazio")
+	}
+	return segs, nil
+}
+
+// Lookup retorna o nó indicado por path, como servers[name=httpbin-1].port,
+// websites[0].url ou database.
+func Lookup(root *yaml.Node, path string) (*yaml.Node, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	n := unwrapDocument(root)
+	if n == nil {
+		return nil, fmt.Errorf("%s: documento vazio", path)
+	}
+	for i, seg := range segs {
+		if n, err = lookupSegment(n, seg, joinSegments(segs[:i])); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// SetPath troca o valor em path por value, mantendo os comentários do nó original.
+// O último campo é criado se ainda não existir no objeto; índices e seletores
+// precisam apontar para itens existentes.
+func SetPath(root *yaml.Node, path string, value *yaml.Node) error {
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	parent := unwrapDocument(root)
+	if parent == nil {
+		return fmt.Errorf("%s: documento vazio", path)
+	}
+	for i, seg := range segs[:len(segs)-1] {
+		if parent, err = lookupSegment(parent, seg, joinSegments(segs[:i])); err != nil {
+			return err
+		}
+	}
+
+	last := segs[len(segs)-1]
+	if last.key != "" && parent.Kind == yaml.MappingNode && mappingIndex(parent, last.key) < 0 {
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last.key}, value)
+		return nil
+	}
+	target, err := lookupSegment(parent, last, joinSegments(segs[:len(segs)-1]))
+	if err != nil {
+		return err
+	}
+	replaceNode(target, value)
+	return nil
+}
+
+// ParseValue interpreta s como um valor YAML ("5" vira inteiro, "[a, b]" uma
+// lista); textos que não são YAML válido viram strings.
+func ParseValue(s string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(s), &doc); err == nil {
+		if n := unwrapDocument(&doc); n != nil {
+			return n
+		}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// CheckValue verifica se value pode ser atribuído ao campo de Config indicado por
+// path, rejeitando campos desconhecidos e tipos incompatíveis. Valores com
+// referências ${...} só são conferidos depois da interpolação, em Load.
+func CheckValue(path string, value *yaml.Node) error {
+	segs, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	t := reflect.TypeOf(Config{})
+	for i, seg := range segs {
+		at := describePath(joinSegments(segs[:i]))
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if seg.key != "" && t.Kind() == reflect.Map {
+			t = t.Elem()
+			continue
+		}
+		if seg.key == "" {
+			if t.Kind() != reflect.Slice {
+				return fmt.Errorf("%s não é uma lista", at)
+			}
+			t = t.Elem()
+			continue
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("%s não é um objeto", at)
+		}
+		field, ok := yamlField(t, seg.key)
+		if !ok {
+			return fmt.Errorf("campo desconhecido %q em %s", seg.key, at)
+		}
+		t = field.Type
+	}
+
+	if value.Kind == yaml.ScalarNode && strings.Contains(value.Value, "${") {
+		return nil
+	}
+	d := &nodeDecoder{file: "valor", strict: true}
+	d.decode(value, reflect.New(t).Elem(), path)
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+func yamlField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0] == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func lookupSegment(n *yaml.Node, seg pathSegment, at string) (*yaml.Node, error) {
+	n = resolveAlias(n)
+	at = describePath(at)
+	switch {
+	case seg.key != "":
+		if n.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s não é um objeto (%s)", at, nodeKind(n))
+		}
+		v := mappingValue(n, seg.key)
+		if v == nil {
+			return nil, fmt.Errorf("campo %q não encontrado em %s", seg.key, at)
+		}
+		return resolveAlias(v), nil
+	case n.Kind != yaml.SequenceNode:
+		return nil, fmt.Errorf("%s não é uma lista (%s)", at, nodeKind(n))
+	case seg.field != "":
+		for _, item := range n.Content {
+			if v := mappingValue(resolveAlias(item), seg.field); v != nil && v.Value == seg.value {
+				return resolveAlias(item), nil
+			}
+		}
+		return nil, fmt.Errorf("nenhum item de %s com %s=%s", at, seg.field, seg.value)
+	}
+	if seg.index >= len(n.Content) {
+		return nil, fmt.Errorf("índice %d fora da lista %s (%d itens)", seg.index, at, len(n.Content))
+	}
+	return resolveAlias(n.Content[seg.index]), nil
+}
+
+func joinSegments(segs []pathSegment) string {
+	var b strings.Builder
+	for i, s := range segs {
+		if i > 0 && s.key != "" {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.String())
+	}
+	return b.String()
+}
+
+func replaceNode(target, value *yaml.Node) {
+	head, line, foot := target.HeadComment, target.LineComment, target.FootComment
+	style := target.Style
+	keepStyle := target.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode && value.Style == 0 && value.ShortTag() == "!!str"
+	*target = *value
+	if keepStyle {
+		// Mantém as aspas originais ao trocar um texto por outro.
+		target.Style = style
+	}
+	if target.HeadComment == "" {
+		target.HeadComment = head
+	}
+	if target.LineComment == "" {
+		target.LineComment = line
+	}
+	if target.FootComment == "" {
+		target.FootComment = foot
+	}
+}
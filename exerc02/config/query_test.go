@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const queryDocument = `servers:
+  # servidor principal
+  - name: app
+    host: app.local
+    port: 80 # porta pública
+    healthcheck: "/health"
+  - name: api
+    host: api.local
+    port: 81
+database:
+  host: localhost
+`
+
+func TestLookup(t *testing.T) {
+	root := parseTestNode(t, queryDocument)
+
+	tests := map[string]string{
+		"servers[name=api].host": "api.local",
+		"servers[0].port":        "80",
+		"database.host":          "localhost",
+	}
+	for path, expected := range tests {
+		n, err := Lookup(root, path)
+		if err != nil {
+			t.Errorf("%s: erro inesperado: %v", path, err)
+			continue
+		}
+		if n.Value != expected {
+			t.Errorf("%s: esperado %q, obtido %q", path, expected, n.Value)
+		}
+	}
+
+	for _, path := range []string{"servers[name=web].port", "servers[5]", "database.port", "database[0]", "servers..name", "servers[x]"} {
+		if _, err := Lookup(root, path); err == nil {
+			t.Errorf("%s: era esperado erro", path)
+		}
+	}
+}
+
+func TestSetPathPreservesComments(t *testing.T) {
+	root := parseTestNode(t, queryDocument)
+
+	if err := SetPath(root, "servers[name=app].port", ParseValue("8080")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPath(root, "servers[name=app].healthcheck", ParseValue("/ready")); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetPath(root, "servers[1].replicas", ParseValue("3")); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Encode(root, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"# servidor principal", "port: 8080 # porta pública", `healthcheck: "/ready"`, "    replicas: 3\ndatabase:"} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("Saída não contém %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	if err := CheckValue("servers[name=app].replicas", ParseValue("5")); err != nil {
+		t.Errorf("Erro inesperado: %v", err)
+	}
+	if err := CheckValue("database.port", ParseValue("${DB_PORT}")); err != nil {
+		t.Errorf("Referências devem ser aceitas: %v", err)
+	}
+	if err := CheckValue("servers[0].port", ParseValue("abc")); err == nil {
+		t.Error("Era esperado erro de tipo")
+	}
+	if err := CheckValue("servers[0].unknown", ParseValue("1")); err == nil {
+		t.Error("Era esperado erro de campo desconhecido")
+	}
+}
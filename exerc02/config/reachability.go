@@ -0,0 +1,119 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Resolver resolve nomes de host em endereços; *net.Resolver atende a interface.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Dialer abre conexões; *net.Dialer atende a interface.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// ReachabilityOptions controla CheckReachability.
+type ReachabilityOptions struct {
+	// Resolver é usado nas consultas DNS (padrão: net.DefaultResolver).
+	Resolver Resolver
+	// Dial abre uma conexão TCP com o primeiro endereço resolvido de cada entrada.
+	Dial   bool
+	Dialer Dialer
+	// Timeout limita cada consulta e cada conexão (padrão: 5s).
+	Timeout time.Duration
+}
+
+// Reachability é o resultado das verificações de uma entrada.
+type Reachability struct {
+	Path      string   `json:"path" yaml:"path"`
+	Name      string   `json:"name" yaml:"name"`
+	Host      string   `json:"host" yaml:"host"`
+	Port      int      `json:"port" yaml:"port"`
+	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	// Reachable só é preenchido quando a conexão TCP foi tentada.
+	Reachable *bool  `json:"reachable,omitempty" yaml:"reachable,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// OK indica se todas as verificações da entrada passaram.
+func (r Reachability) OK() bool {
+	return r.Error == ""
+}
+
+// CheckReachability verifica, em paralelo, se o host de cada servidor e do banco
+// de dados resolve no DNS e se a porta está no intervalo válido; com Dial, também
+// se a porta aceita conexões. Os resultados seguem a ordem do arquivo.
+func CheckReachability(ctx context.Context, cfg Config, opts ReachabilityOptions) []Reachability {
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	if opts.Dialer == nil {
+		opts.Dialer = &net.Dialer{}
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	var results []Reachability
+	for i, s := range cfg.Servers {
+		results = append(results, Reachability{Path: fmt.Sprintf("servers[%d]", i), Name: s.Name, Host: s.Host, Port: s.Port})
+	}
+	if db := cfg.Database; db != (DatabaseConfig{}) {
+		results = append(results, Reachability{Path: "database", Name: "database", Host: db.Host, Port: db.Port})
+	}
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(r *Reachability) {
+			defer wg.Done()
+			checkEntry(ctx, r, opts)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+func checkEntry(ctx context.Context, r *Reachability, opts ReachabilityOptions) {
+	if r.Host == "" {
+		r.Error = "host não informado"
+		return
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	addrs, err := opts.Resolver.LookupHost(lookupCtx, r.Host)
+	cancel()
+	if err == nil && len(addrs) == 0 {
+		err = fmt.Errorf("nenhum endereço para %s", r.Host)
+	}
+	if err != nil {
+		r.Error = fmt.Sprintf("DNS: %v", err)
+		return
+	}
+	r.Addresses = addrs
+
+	if r.Port < 1 || r.Port > 65535 {
+		r.Error = fmt.Sprintf("porta %d fora do intervalo 1-65535", r.Port)
+		return
+	}
+	if !opts.Dial {
+		return
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	conn, err := opts.Dialer.DialContext(dialCtx, "tcp", net.JoinHostPort(addrs[0], strconv.Itoa(r.Port)))
+	reachable := err == nil
+	r.Reachable = &reachable
+	if err != nil {
+		r.Error = fmt.Sprintf("TCP: %v", err)
+		return
+	}
+	conn.Close()
+}
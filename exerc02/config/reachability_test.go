@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// fakeDialer aceita conexões somente nos endereços listados.
+type fakeDialer map[string]bool
+
+func (d fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if !d[address] {
+		return nil, errors.New("connection refused")
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func TestCheckReachability(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "web", Host: "web.local", Port: 443},
+			{Name: "missing", Host: "missing.local", Port: 443},
+			{Name: "badport", Host: "web.local", Port: 70000},
+			{Name: "closed", Host: "closed.local", Port: 8080},
+		},
+		Database: DatabaseConfig{Host: "db.local", Port: 5432, User: "admin"},
+	}
+	resolver := fakeResolver{
+		"web.local":    {"10.0.0.1"},
+		"closed.local": {"10.0.0.2"},
+		"db.local":     {"10.0.0.3"},
+	}
+
+	results := CheckReachability(context.Background(), cfg, ReachabilityOptions{Resolver: resolver})
+	if len(results) != 5 {
+		t.Fatalf("Esperados 5 resultados, obtidos %d", len(results))
+	}
+	wantErr := map[string]string{"servers[1]": "DNS", "servers[2]": "fora do intervalo"}
+	for _, r := range results {
+		want := wantErr[r.Path]
+		if want == "" && !r.OK() || want != "" && !strings.Contains(r.Error, want) {
+			t.Errorf("%s: erro %q, esperado %q", r.Path, r.Error, want)
+		}
+		if r.Reachable != nil {
+			t.Errorf("%s: conexão tentada sem Dial", r.Path)
+		}
+	}
+	if results[4].Path != "database" || results[4].Addresses[0] != "10.0.0.3" {
+		t.Errorf("Resultado inesperado para o banco de dados: %+v", results[4])
+	}
+
+	dialer := fakeDialer{"10.0.0.1:443": true, "10.0.0.3:5432": true}
+	results = CheckReachability(context.Background(), cfg, ReachabilityOptions{Resolver: resolver, Dial: true, Dialer: dialer})
+	closed := results[3]
+	if closed.Reachable == nil || *closed.Reachable || !strings.Contains(closed.Error, "TCP") {
+		t.Errorf("Esperada falha de conexão em closed: %+v", closed)
+	}
+	if !results[0].OK() || results[0].Reachable == nil || !*results[0].Reachable {
+		t.Errorf("Esperada conexão com web: %+v", results[0])
+	}
+}
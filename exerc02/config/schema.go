@@ -0,0 +1,298 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema é o subconjunto de JSON Schema (draft 2020-12) usado pelo configparser.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+}
+
+// GenerateSchema monta o JSON Schema de Config a partir das tags json e jsonschema.
+// O schema descreve o arquivo como escrito: os campos que a seção defaults pode
+// preencher não são obrigatórios nos servidores e websites.
+func GenerateSchema() *Schema {
+	s := schemaForType(reflect.TypeOf(Config{}))
+	s.Schema = SchemaDialect
+	s.Title = "configparser"
+	s.Properties["servers"].Items.optional(reflect.TypeOf(ServerDefaults{}))
+	s.Properties["websites"].Items.optional(reflect.TypeOf(WebsiteDefaults{}))
+	return s
+}
+
+// optional remove de Required os campos de t.
+func (s *Schema) optional(t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		s.Required = slices.DeleteFunc(s.Required, func(r string) bool { return r == name })
+	}
+}
+
+func schemaForType(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		closed := false
+		s.AdditionalProperties = &closed
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			prop := schemaForType(field.Type)
+			if applySchemaTag(prop, field.Tag.Get("jsonschema")) {
+				s.Required = append(s.Required, name)
+			}
+			s.Properties[name] = prop
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Struct {
+			return &Schema{Type: "object", PatternProperties: map[string]*Schema{".*": schemaForType(t.Elem())}}
+		}
+		return &Schema{Type: "object"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	return &Schema{}
+}
+
+// applySchemaTag aplica as restrições da tag jsonschema e indica se o campo é obrigatório.
+func applySchemaTag(s *Schema, tag string) bool {
+	required := false
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			required = true
+		case "minimum":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Minimum = &f
+			}
+		case "maximum":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Maximum = &f
+			}
+		case "minLength":
+			if n, err := strconv.Atoi(value); err == nil {
+				s.MinLength = &n
+			}
+		case "pattern":
+			s.Pattern = value
+		case "format":
+			s.Format = value
+		case "enum":
+			for _, v := range strings.Split(value, "|") {
+				s.Enum = append(s.Enum, v)
+			}
+		}
+	}
+	return required
+}
+
+// ParseSchema lê um JSON Schema previamente gerado (e possivelmente estendido).
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("schema inválido: %w", err)
+	}
+	return &s, nil
+}
+
+// Validate verifica um documento genérico (resultado de yaml/json Unmarshal em any)
+// contra o schema.
+func (s *Schema) Validate(doc any) ValidationErrors {
+	var errs ValidationErrors
+	s.validate(doc, "", &errs)
+	return errs
+}
+
+func (s *Schema) validate(value any, path string, errs *ValidationErrors) {
+	where := path
+	if where == "" {
+		where = "$"
+	}
+
+	if s.Type != "" && !matchesType(s.Type, value) {
+		errs.add(where, "type", fmt.Sprintf("esperado %s, encontrado %s", s.Type, typeName(value)), SeverityError)
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		errs.add(where, "enum", fmt.Sprintf("valor %v não permitido (permitidos: %v)", value, s.Enum), SeverityError)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs.add(joinPath(path, name), "required", "campo obrigatório ausente", SeverityError)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			prop, ok := s.Properties[k]
+			for pattern, p := range s.PatternProperties {
+				if re, err := regexp.Compile(pattern); !ok && err == nil && re.MatchString(k) {
+					prop, ok = p, true
+				}
+			}
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					errs.add(joinPath(path, k), "additionalProperties", "campo desconhecido", SeverityError)
+				}
+				continue
+			}
+			prop.validate(v[k], joinPath(path, k), errs)
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			errs.add(where, "minLength", fmt.Sprintf("tamanho mínimo %d", *s.MinLength), SeverityError)
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				errs.add(where, "pattern", fmt.Sprintf("valor não corresponde a %s", s.Pattern), SeverityError)
+			}
+		}
+		if s.Format == "uri" {
+			if u, err := url.Parse(v); err != nil || u.Scheme == "" {
+				errs.add(where, "format", fmt.Sprintf("uri inválida: %q", v), SeverityError)
+			}
+		}
+	default:
+		if n, ok := toFloat(v); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				errs.add(where, "minimum", fmt.Sprintf("valor %v menor que %v", v, *s.Minimum), SeverityError)
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				errs.add(where, "maximum", fmt.Sprintf("valor %v maior que %v", v, *s.Maximum), SeverityError)
+			}
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func matchesType(t string, v any) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "null":
+		return v == nil
+	case "number":
+		_, ok := toFloat(v)
+		return ok
+	case "integer":
+		n, ok := toFloat(v)
+		return ok && n == math.Trunc(n)
+	}
+	return true
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if n, ok := toFloat(v); ok {
+		if n == math.Trunc(n) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func inEnum(enum []any, v any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateSchemaFromTags(t *testing.T) {
+	s := GenerateSchema()
+	if s.Schema != SchemaDialect {
+		t.Errorf("Dialeto inesperado: %s", s.Schema)
+	}
+
+	server := s.Properties["servers"].Items
+	if server == nil || server.Type != "object" {
+		t.Fatal("Schema de servers deve ser um array de objetos")
+	}
+	port := server.Properties["port"]
+	if port.Type != "integer" || *port.Minimum != 1 || *port.Maximum != 65535 {
+		t.Errorf("Restrições de porta inesperadas: %+v", port)
+	}
+	// host e port podem vir de defaults.servers.
+	if len(server.Required) != 1 || server.Required[0] != "name" {
+		t.Errorf("Campos obrigatórios inesperados: %v", server.Required)
+	}
+}
+
+func TestSchemaAcceptsDefaults(t *testing.T) {
+	doc := `
+defaults:
+  servers:
+    port: 443
+  groups:
+    web:
+      servers:
+        protocol: https
+    db:
+      servers:
+        prot: tcp
+servers:
+  - name: app
+    host: localhost
+    tags: [web]
+database:
+  host: localhost
+  port: 5432
+  user: admin
+`
+	var v any
+	if err := yaml.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatal(err)
+	}
+	errs := GenerateSchema().Validate(v)
+	if len(errs) != 1 || errs[0].Path != "defaults.groups.db.servers.prot" {
+		t.Errorf("Esperado somente o campo desconhecido no grupo db, obtido %v", errs)
+	}
+
+	// O exemplo informa a porta dos servidores só em defaults.servers.
+	data, err := os.ReadFile("../example_config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if errs := GenerateSchema().Validate(v); len(errs) > 0 {
+		t.Errorf("example_config.yaml deveria ser válido contra o schema: %v", errs)
+	}
+}
+
+func TestSchemaRoundTripAndValidate(t *testing.T) {
+	data, err := json.Marshal(GenerateSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := ParseSchema(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := `
+servers:
+  - name: app
+    host: localhost
+    port: "8080"
+    helthcheck: /health
+database:
+  host: localhost
+  port: 5432
+`
+	var v any
+	if err := yaml.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"servers[0].port":       "type",
+		"servers[0].helthcheck": "additionalProperties",
+		"database.user":         "required",
+	}
+	for _, e := range s.Validate(v) {
+		if expected[e.Path] == e.Rule {
+			delete(expected, e.Path)
+		}
+	}
+	for path, rule := range expected {
+		t.Errorf("Erro %s não reportado para %s", rule, path)
+	}
+}
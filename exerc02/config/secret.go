@@ -0,0 +1,243 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedValue substitui campos marcados com a tag secret em qualquer saída.
+const RedactedValue = "******"
+
+// KeySize é o tamanho da chave AES-256 usada nos valores ENC[...].
+const KeySize = 32
+
+var encryptedRe = regexp.MustCompile(`^ENC\[AES256_GCM,data:([A-Za-z0-9+/=]*),iv:([A-Za-z0-9+/=]+),tag:([A-Za-z0-9+/=]+)\]$`)
+
+// Redact retorna uma cópia de cfg com os campos secretos mascarados.
+func Redact(cfg Config) Config {
+	v := reflect.ValueOf(&cfg).Elem()
+	redactValue(v)
+	return cfg
+}
+
+func redactValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if v.Type().Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String {
+				if field.String() != "" {
+					field.SetString(RedactedValue)
+				}
+				continue
+			}
+			redactValue(field)
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(copied, v)
+		for i := 0; i < copied.Len(); i++ {
+			redactValue(copied.Index(i))
+		}
+		v.Set(copied)
+	}
+}
+
+// GenerateKey cria uma nova chave aleatória.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// EncodeKey formata a chave para gravação em arquivo.
+func EncodeKey(key []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(key) + "\n")
+}
+
+// LoadKey lê uma chave em base64, hexadecimal ou bytes brutos.
+func LoadKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == KeySize {
+		return data, nil
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(text); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%s: a chave deve ter %d bytes (brutos, base64 ou hex)", path, KeySize)
+}
+
+// IsEncrypted indica se o valor está no formato ENC[AES256_GCM,...].
+func IsEncrypted(value string) bool {
+	return encryptedRe.MatchString(value)
+}
+
+// EncryptValue cifra value com AES-256-GCM.
+func EncryptValue(value string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nil, iv, []byte(value), nil)
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	enc := base64.StdEncoding.EncodeToString
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s]", enc(data), enc(iv), enc(tag)), nil
+}
+
+// DecryptValue decifra um valor produzido por EncryptValue.
+func DecryptValue(value string, key []byte) (string, error) {
+	m := encryptedRe.FindStringSubmatch(value)
+	if m == nil {
+		return "", errors.New("valor não está no formato ENC[AES256_GCM,...]")
+	}
+	var parts [3][]byte
+	for i := range parts {
+		b, err := base64.StdEncoding.DecodeString(m[i+1])
+		if err != nil {
+			return "", fmt.Errorf("valor cifrado inválido: %w", err)
+		}
+		parts[i] = b
+	}
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(iv) != gcm.NonceSize() || len(tag) != gcm.Overhead() {
+		return "", errors.New("valor cifrado inválido: tamanho de iv ou tag incorreto")
+	}
+	plain, err := gcm.Open(nil, iv, append(data, tag...), nil)
+	if err != nil {
+		return "", errors.New("não foi possível decifrar o valor (chave incorreta ou valor adulterado)")
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptNode cifra, na árvore, os valores dos campos marcados com a tag secret
+// que ainda não estão cifrados, e retorna quantos foram alterados.
+func EncryptNode(root *yaml.Node, key []byte) (int, error) {
+	count := 0
+	var walk func(n *yaml.Node, t reflect.Type) error
+	walk = func(n *yaml.Node, t reflect.Type) error {
+		n = resolveAlias(n)
+		switch t.Kind() {
+		case reflect.Struct:
+			if n.Kind != yaml.MappingNode {
+				return nil
+			}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				value := mappingValue(n, strings.Split(field.Tag.Get("yaml"), ",")[0])
+				if value == nil {
+					continue
+				}
+				if field.Tag.Get("secret") == "true" {
+					if value.Kind != yaml.ScalarNode || value.ShortTag() == "!!null" || IsEncrypted(value.Value) {
+						continue
+					}
+					enc, err := EncryptValue(value.Value, key)
+					if err != nil {
+						return err
+					}
+					value.Value, value.Tag, value.Style = enc, "!!str", 0
+					count++
+					continue
+				}
+				if err := walk(value, field.Type); err != nil {
+					return err
+				}
+			}
+		case reflect.Slice:
+			if n.Kind != yaml.SequenceNode {
+				return nil
+			}
+			for _, item := range n.Content {
+				if err := walk(item, t.Elem()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if n := unwrapDocument(root); n != nil {
+		if err := walk(n, reflect.TypeOf(Config{})); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// HasEncryptedValues indica se a árvore contém algum valor ENC[...].
+func HasEncryptedValues(n *yaml.Node) bool {
+	if n.Kind == yaml.ScalarNode {
+		return IsEncrypted(n.Value)
+	}
+	for _, c := range n.Content {
+		if HasEncryptedValues(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecryptNode decifra todos os valores ENC[...] da árvore e retorna quantos foram alterados.
+func DecryptNode(filename string, n *yaml.Node, key []byte) (int, error) {
+	if n.Kind == yaml.ScalarNode {
+		if !IsEncrypted(n.Value) {
+			return 0, nil
+		}
+		plain, err := DecryptValue(n.Value, key)
+		if err != nil {
+			return 0, &DecodeError{File: filename, Line: n.Line, Column: n.Column, Message: err.Error()}
+		}
+		n.Value, n.Tag, n.Style = plain, "!!str", 0
+		return 1, nil
+	}
+	count := 0
+	for _, c := range n.Content {
+		c, err := DecryptNode(filename, c, key)
+		count += c
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
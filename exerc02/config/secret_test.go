@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	cfg := Config{
+		Servers:  []ServerConfig{{Name: "app"}},
+		Database: DatabaseConfig{Host: "localhost", Password: "secret"},
+	}
+	redacted := Redact(cfg)
+	if redacted.Database.Password != RedactedValue {
+		t.Errorf("Senha deveria ser mascarada: %q", redacted.Database.Password)
+	}
+	if cfg.Database.Password != "secret" || redacted.Database.Host != "localhost" {
+		t.Error("Redact não deve alterar o original nem campos não secretos")
+	}
+}
+
+func TestEncryptDecryptValue(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := EncryptValue("secret", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(enc) || strings.Contains(enc, "secret") {
+		t.Fatalf("Valor cifrado inesperado: %s", enc)
+	}
+
+	plain, err := DecryptValue(enc, key)
+	if err != nil || plain != "secret" {
+		t.Errorf("DecryptValue = %q, %v", plain, err)
+	}
+
+	other, _ := GenerateKey()
+	if _, err := DecryptValue(enc, other); err == nil {
+		t.Error("Decifrar com outra chave deveria falhar")
+	}
+}
+
+func TestEncryptNodeOnlySecretFields(t *testing.T) {
+	key, _ := GenerateKey()
+	root := parseTestNode(t, `database:
+  host: localhost
+  user: admin
+  password: secret # senha
+`)
+	count, err := EncryptNode(root, key)
+	if err != nil || count != 1 {
+		t.Fatalf("EncryptNode = %d, %v", count, err)
+	}
+	out, _ := Encode(root, FormatYAML)
+	if !strings.Contains(string(out), "user: admin") || !strings.Contains(string(out), "password: ENC[AES256_GCM,") || !strings.Contains(string(out), "# senha") {
+		t.Errorf("Saída cifrada inesperada:\n%s", out)
+	}
+
+	if count, err := DecryptNode("test.yaml", root, key); err != nil || count != 1 {
+		t.Fatalf("DecryptNode = %d, %v", count, err)
+	}
+	var cfg Config
+	if err := DecodeNode("test.yaml", root, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Password != "secret" {
+		t.Errorf("Senha decifrada incorreta: %q", cfg.Database.Password)
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Requirement é uma condição de um Selector sobre os labels e tags de uma entrada.
+type Requirement struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// Operadores de Requirement. OpExists e OpNotExists aceitam tanto uma tag quanto
+// uma chave de label.
+const (
+	OpEquals    = "="
+	OpNotEquals = "!="
+	OpExists    = "exists"
+	OpNotExists = "!exists"
+)
+
+func (r Requirement) String() string {
+	switch r.Op {
+	case OpExists:
+		return r.Key
+	case OpNotExists:
+		return "!" + r.Key
+	}
+	return r.Key + r.Op + r.Value
+}
+
+func (r Requirement) matches(tags []string, labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Op {
+	case OpEquals:
+		return ok && value == r.Value
+	case OpNotEquals:
+		return !ok || value != r.Value
+	case OpExists:
+		return ok || slices.Contains(tags, r.Key)
+	case OpNotExists:
+		return !ok && !slices.Contains(tags, r.Key)
+	}
+	return false
+}
+
+// Selector seleciona entradas pelos labels e tags; todas as condições precisam
+// ser atendidas. O Selector vazio seleciona tudo.
+type Selector []Requirement
+
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, r := range s {
+		parts[i] = r.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseSelector lê condições separadas por vírgula: env=prod, tier!=db, web
+// (tag ou label presente) e !canary (ausente).
+func ParseSelector(expr string) (Selector, error) {
+	var s Selector
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var r Requirement
+		if key, value, ok := strings.Cut(part, "!="); ok {
+			r = Requirement{Key: key, Op: OpNotEquals, Value: value}
+		} else if key, value, ok := strings.Cut(part, "="); ok {
+			r = Requirement{Key: key, Op: OpEquals, Value: strings.TrimPrefix(value, "=")}
+		} else if key, ok := strings.CutPrefix(part, "!"); ok {
+			r = Requirement{Key: key, Op: OpNotExists}
+		} else {
+			r = Requirement{Key: part, Op: OpExists}
+		}
+		r.Key, r.Value = strings.TrimSpace(r.Key), strings.TrimSpace(r.Value)
+		if r.Key == "" || strings.ContainsAny(r.Key, "!= ") {
+			return nil, fmt.Errorf("seletor inválido %q", part)
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+// Matches indica se uma entrada com essas tags e labels atende a todas as condições.
+func (s Selector) Matches(tags []string, labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(tags, labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter seleciona servidores e websites por Selector e pelo nome, com padrões
+// como httpbin-* (basta um dos padrões casar).
+type Filter struct {
+	Selector Selector
+	Names    []string
+}
+
+// NewFilter valida o seletor e os padrões de nome.
+func NewFilter(selector string, names []string) (Filter, error) {
+	s, err := ParseSelector(selector)
+	if err != nil {
+		return Filter{}, err
+	}
+	for _, n := range names {
+		if _, err := path.Match(n, ""); err != nil {
+			return Filter{}, fmt.Errorf("padrão de nome inválido %q", n)
+		}
+	}
+	return Filter{Selector: s, Names: names}, nil
+}
+
+func (f Filter) matches(name string, tags []string, labels map[string]string) bool {
+	if !f.Selector.Matches(tags, labels) {
+		return false
+	}
+	if len(f.Names) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.Names, func(pattern string) bool {
+		ok, _ := path.Match(pattern, name)
+		return ok
+	})
+}
+
+// Apply retorna cfg somente com os servidores e websites selecionados.
+func (f Filter) Apply(cfg Config) Config {
+	filtered := cfg
+	filtered.Servers, filtered.Website = nil, nil
+	for _, s := range cfg.Servers {
+		if f.matches(s.Name, s.Tags, s.Labels) {
+			filtered.Servers = append(filtered.Servers, s)
+		}
+	}
+	for _, w := range cfg.Website {
+		if f.matches(w.Name, w.Tags, w.Labels) {
+			filtered.Website = append(filtered.Website, w)
+		}
+	}
+	return filtered
+}
+
+var entryPathRe = regexp.MustCompile(`^(servers|websites)\[(\d+)\]`)
+
+// Selects indica se path (de um ValidationError ou de um Reachability de cfg, não
+// filtrado) pertence a uma entrada aceita pelo filtro. Caminhos fora de servers e
+// websites, como database, são sempre aceitos.
+func (f Filter) Selects(cfg Config, path string) bool {
+	m := entryPathRe.FindStringSubmatch(path)
+	if m == nil {
+		return true
+	}
+	i, _ := strconv.Atoi(m[2])
+	if m[1] == "servers" && i < len(cfg.Servers) {
+		s := cfg.Servers[i]
+		return f.matches(s.Name, s.Tags, s.Labels)
+	}
+	if m[1] == "websites" && i < len(cfg.Website) {
+		w := cfg.Website[i]
+		return f.matches(w.Name, w.Tags, w.Labels)
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package config
+
+import "testing"
+
+func TestParseSelector(t *testing.T) {
+	s, err := ParseSelector("env=prod, tier!=db,web,!canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 4 || s.String() != "env=prod,tier!=db,web,!canary" {
+		t.Errorf("Seletor inesperado: %v", s)
+	}
+	for _, invalid := range []string{"=prod", "env prod", "!"} {
+		if _, err := ParseSelector(invalid); err == nil {
+			t.Errorf("Esperado erro para o seletor %q", invalid)
+		}
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "httpbin-1", Tags: []string{"web"}, Labels: map[string]string{"env": "prod", "tier": "front"}},
+			{Name: "httpbin-2", Tags: []string{"web", "canary"}, Labels: map[string]string{"env": "prod"}},
+			{Name: "postgres", Labels: map[string]string{"env": "prod", "tier": "db"}},
+			{Name: "httpbin-3", Tags: []string{"web"}, Labels: map[string]string{"env": "dev"}},
+		},
+		Website: []WebsiteConfig{
+			{Name: "GitHub", Labels: map[string]string{"env": "prod"}},
+			{Name: "Example"},
+		},
+	}
+
+	filter, err := NewFilter("env=prod,tier!=db,!canary", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered := filter.Apply(cfg)
+	if len(filtered.Servers) != 1 || filtered.Servers[0].Name != "httpbin-1" {
+		t.Errorf("Servidores inesperados: %v", filtered.Servers)
+	}
+	if len(filtered.Website) != 1 || filtered.Website[0].Name != "GitHub" {
+		t.Errorf("Websites inesperados: %v", filtered.Website)
+	}
+
+	filter, err = NewFilter("web", []string{"httpbin-[23]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered = filter.Apply(cfg)
+	if len(filtered.Servers) != 2 || filtered.Servers[0].Name != "httpbin-2" || filtered.Servers[1].Name != "httpbin-3" {
+		t.Errorf("Servidores inesperados: %v", filtered.Servers)
+	}
+	if len(filtered.Website) != 0 {
+		t.Errorf("Nenhum website deveria ser selecionado: %v", filtered.Website)
+	}
+	for path, want := range map[string]bool{"servers[0].port": false, "servers[2]": false, "servers[3]": true, "websites[1].url": false, "database.user": true} {
+		if filter.Selects(cfg, path) != want {
+			t.Errorf("Selects(%q) deveria ser %v", path, want)
+		}
+	}
+
+	if _, err := NewFilter("", []string{"[invalid"}); err == nil {
+		t.Error("Esperado erro para padrão de nome inválido")
+	}
+}
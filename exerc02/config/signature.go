@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// SignatureExt é a extensão da assinatura destacada, gravada ao lado do arquivo.
+const SignatureExt = ".sig"
+
+var (
+	ErrUnsigned         = errors.New("arquivo sem assinatura")
+	ErrInvalidSignature = errors.New("assinatura inválida: o arquivo foi alterado ou assinado com outra chave")
+)
+
+// SignatureFile retorna o caminho da assinatura destacada de path.
+func SignatureFile(path string) string {
+	return path + SignatureExt
+}
+
+// GenerateSigningKey cria um novo par de chaves ed25519.
+func GenerateSigningKey() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	return ed25519.GenerateKey(rand.Reader)
+}
+
+// EncodePrivateKey formata a chave privada em PEM (PKCS #8), o mesmo formato de
+// openssl genpkey -algorithm ed25519.
+func EncodePrivateKey(key ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// EncodePublicKey formata a chave pública em PEM (PKIX).
+func EncodePublicKey(key ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// LoadPrivateKey lê uma chave privada ed25519 em PEM.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if k, ok := key.(ed25519.PrivateKey); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%s: a chave não é ed25519", path)
+}
+
+// LoadPublicKey lê uma chave pública ed25519 em PEM.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if k, ok := key.(ed25519.PublicKey); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("%s: a chave não é ed25519", path)
+}
+
+func readPEM(path, blockType string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s: esperado um bloco PEM %s", path, blockType)
+	}
+	return block.Bytes, nil
+}
+
+// Sign assina data e retorna o conteúdo da assinatura destacada (base64).
+func Sign(data []byte, key ed25519.PrivateKey) []byte {
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return []byte(sig + "\n")
+}
+
+// Verify confere a assinatura produzida por Sign para data.
+func Verify(data, signature []byte, key ed25519.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return errors.New("assinatura mal formada")
+	}
+	if !ed25519.Verify(key, data, sig) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyFile confere o arquivo contra a assinatura em SignatureFile(path).
+// Retorna ErrUnsigned se a assinatura não existe.
+func VerifyFile(path string, key ed25519.PublicKey) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return VerifyData(path, data, key)
+}
+
+// VerifyData é como VerifyFile, mas confere o conteúdo já lido de path. Quem vai
+// usar o conteúdo deve verificá-lo assim, para não ler o arquivo duas vezes.
+func VerifyData(path string, data []byte, key ed25519.PublicKey) error {
+	sig, err := os.ReadFile(SignatureFile(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: %w", path, ErrUnsigned)
+	}
+	if err != nil {
+		return err
+	}
+	if err := Verify(data, sig, key); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSignAndVerifyFile(t *testing.T) {
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	privPEM, _ := EncodePrivateKey(priv)
+	pubPEM, _ := EncodePublicKey(pub)
+	os.WriteFile(filepath.Join(dir, "key"), privPEM, 0600)
+	os.WriteFile(filepath.Join(dir, "key.pub"), pubPEM, 0644)
+	if priv, err = LoadPrivateKey(filepath.Join(dir, "key")); err != nil {
+		t.Fatal(err)
+	}
+	if pub, err = LoadPublicKey(filepath.Join(dir, "key.pub")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPublicKey(filepath.Join(dir, "key")); err == nil {
+		t.Error("Esperado erro ao ler a chave privada como pública")
+	}
+
+	path := writeTestFile(t, "config.yaml", "servers: []\n")
+	if err := VerifyFile(path, pub); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Esperado ErrUnsigned, obtido %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	os.WriteFile(SignatureFile(path), Sign(data, priv), 0644)
+	if err := VerifyFile(path, pub); err != nil {
+		t.Errorf("Assinatura deveria ser válida: %v", err)
+	}
+
+	os.WriteFile(path, []byte("servers: [x]\n"), 0644)
+	if err := VerifyFile(path, pub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Esperado ErrInvalidSignature para arquivo alterado, obtido %v", err)
+	}
+
+	otherPub, _, _ := GenerateSigningKey()
+	if err := Verify(data, Sign(data, priv), otherPub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Esperado ErrInvalidSignature para outra chave, obtido %v", err)
+	}
+}
+
+func TestLoadWithPublicKey(t *testing.T) {
+	pub, priv, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeTestFile(t, "config.yaml", "database:\n  host: localhost\n  port: 5432\n  user: admin\n")
+	if _, err := Load(path, WithPublicKey(pub)); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Esperado ErrUnsigned, obtido %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	os.WriteFile(SignatureFile(path), Sign(data, priv), 0644)
+	if _, err := Load(path, WithPublicKey(pub)); err != nil {
+		t.Errorf("Arquivo assinado deveria ser carregado: %v", err)
+	}
+	if _, err := Load(StdinPath, WithPublicKey(pub), WithStdin(strings.NewReader(string(data)))); err == nil {
+		t.Error("A entrada padrão não deveria ser aceita com WithPublicKey")
+	}
+}
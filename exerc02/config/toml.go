@@ -0,0 +1,365 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+)
+
+// parseTOML converte um documento TOML em uma árvore yaml.Node mantendo a ordem
+// das chaves, para que o restante do pipeline trate os três formatos igualmente.
+func parseTOML(filename string, data []byte) (*yaml.Node, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1, Column: 1}
+	current := root
+
+	p := &unstable.Parser{}
+	p.Reset(data)
+	for p.NextExpression() {
+		expr := p.Expression()
+		line, col := tomlPosition(p, expr)
+
+		var err error
+		switch expr.Kind {
+		case unstable.Table:
+			current, err = tomlDescend(root, tomlKey(expr.Key()), line, col)
+		case unstable.ArrayTable:
+			current, err = tomlArrayTable(root, tomlKey(expr.Key()), line, col)
+		case unstable.KeyValue:
+			err = tomlKeyValue(p, current, expr, line, col)
+		}
+		if err != nil {
+			return nil, &DecodeError{File: filename, Line: line, Column: col, Message: err.Error()}
+		}
+	}
+
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) && len(perr.Highlight) > 0 {
+			shape := p.Shape(p.Range(perr.Highlight))
+			return nil, &DecodeError{File: filename, Line: shape.Start.Line, Column: shape.Start.Column, Message: perr.Message}
+		}
+		return nil, &DecodeError{File: filename, Line: 1, Message: err.Error()}
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}, Line: 1, Column: 1}, nil
+}
+
+func tomlPosition(p *unstable.Parser, n *unstable.Node) (int, int) {
+	for it := n; it != nil; it = it.Child() {
+		if it.Raw.Length > 0 {
+			shape := p.Shape(it.Raw)
+			return shape.Start.Line, shape.Start.Column
+		}
+	}
+	return 0, 0
+}
+
+func tomlKey(it unstable.Iterator) []string {
+	var parts []string
+	for it.Next() {
+		parts = append(parts, string(it.Node().Data))
+	}
+	return parts
+}
+
+// tomlDescend percorre (criando quando necessário) as tabelas de path a partir de n.
+func tomlDescend(n *yaml.Node, path []string, line, col int) (*yaml.Node, error) {
+	for _, key := range path {
+		child := mappingValue(n, key)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line, Column: col}
+			n.Content = append(n.Content, tomlScalar(key, "!!str", line, col), child)
+		}
+		if child.Kind == yaml.SequenceNode && len(child.Content) > 0 {
+			child = child.Content[len(child.Content)-1]
+		}
+		if child.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("chave %q já definida com outro tipo", key)
+		}
+		n = child
+	}
+	return n, nil
+}
+
+func tomlArrayTable(root *yaml.Node, key []string, line, col int) (*yaml.Node, error) {
+	parent, err := tomlDescend(root, key[:len(key)-1], line, col)
+	if err != nil {
+		return nil, err
+	}
+	name := key[len(key)-1]
+	seq := mappingValue(parent, name)
+	if seq == nil {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line, Column: col}
+		parent.Content = append(parent.Content, tomlScalar(name, "!!str", line, col), seq)
+	}
+	if seq.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("chave %q já definida com outro tipo", name)
+	}
+	item := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line, Column: col}
+	seq.Content = append(seq.Content, item)
+	return item, nil
+}
+
+func tomlKeyValue(p *unstable.Parser, table *yaml.Node, expr *unstable.Node, line, col int) error {
+	key := tomlKey(expr.Key())
+	parent, err := tomlDescend(table, key[:len(key)-1], line, col)
+	if err != nil {
+		return err
+	}
+	name := key[len(key)-1]
+	if mappingValue(parent, name) != nil {
+		return fmt.Errorf("chave duplicada %q", name)
+	}
+	value, err := tomlValue(p, expr.Value(), line, col)
+	if err != nil {
+		return err
+	}
+	parent.Content = append(parent.Content, tomlScalar(name, "!!str", line, col), value)
+	return nil
+}
+
+func tomlValue(p *unstable.Parser, n *unstable.Node, line, col int) (*yaml.Node, error) {
+	data := string(n.Data)
+	switch n.Kind {
+	case unstable.String:
+		return tomlScalar(data, "!!str", line, col), nil
+	case unstable.Bool:
+		return tomlScalar(data, "!!bool", line, col), nil
+	case unstable.Integer:
+		i, err := strconv.ParseInt(strings.ReplaceAll(data, "_", ""), 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("inteiro inválido %q", data)
+		}
+		return tomlScalar(strconv.FormatInt(i, 10), "!!int", line, col), nil
+	case unstable.Float:
+		switch strings.TrimLeft(data, "+") {
+		case "inf":
+			return tomlScalar(".inf", "!!float", line, col), nil
+		case "-inf":
+			return tomlScalar("-.inf", "!!float", line, col), nil
+		case "nan", "-nan":
+			return tomlScalar(".nan", "!!float", line, col), nil
+		}
+		return tomlScalar(strings.ReplaceAll(data, "_", ""), "!!float", line, col), nil
+	case unstable.LocalDate, unstable.LocalTime, unstable.LocalDateTime, unstable.DateTime:
+		return tomlScalar(data, "!!str", line, col), nil
+	case unstable.Array:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: line, Column: col}
+		it := n.Children()
+		for it.Next() {
+			item, err := tomlValue(p, it.Node(), line, col)
+			if err != nil {
+				return nil, err
+			}
+			seq.Content = append(seq.Content, item)
+		}
+		return seq, nil
+	case unstable.InlineTable:
+		m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line, Column: col}
+		it := n.Children()
+		for it.Next() {
+			if err := tomlKeyValue(p, m, it.Node(), line, col); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("valor TOML não suportado: %s", n.Kind)
+}
+
+func tomlScalar(value, tag string, line, col int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, Line: line, Column: col}
+}
+
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeTOML serializa uma árvore yaml.Node em TOML. Pares chave/valor de cada
+// tabela vêm antes das subtabelas, como exige o formato; fora isso a ordem é mantida.
+func encodeTOML(n *yaml.Node) ([]byte, error) {
+	n = unwrapDocument(n)
+	if n == nil {
+		return nil, nil
+	}
+	if n.Kind != yaml.MappingNode {
+		return nil, errors.New("TOML exige um objeto na raiz do documento")
+	}
+	var buf bytes.Buffer
+	if err := tomlWriteTable(&buf, nil, n); err != nil {
+		return nil, err
+	}
+	return bytes.TrimLeft(buf.Bytes(), "\n"), nil
+}
+
+func tomlWriteTable(buf *bytes.Buffer, path []string, n *yaml.Node) error {
+	type subtable struct {
+		key   string
+		value *yaml.Node
+	}
+	var subtables []subtable
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i].Value, resolveAlias(n.Content[i+1])
+		switch {
+		case value.Kind == yaml.MappingNode,
+			value.Kind == yaml.SequenceNode && len(value.Content) > 0 && allMappings(value):
+			subtables = append(subtables, subtable{key, value})
+		case value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null":
+			// TOML não representa null; a chave é omitida.
+		default:
+			v, err := tomlInline(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(append(path, key), "."), err)
+			}
+			fmt.Fprintf(buf, "%s = %s\n", tomlQuoteKey(key), v)
+		}
+	}
+
+	for _, t := range subtables {
+		sub := append(append([]string(nil), path...), t.key)
+		if t.value.Kind == yaml.MappingNode {
+			fmt.Fprintf(buf, "\n[%s]\n", tomlJoinKey(sub))
+			if err := tomlWriteTable(buf, sub, t.value); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, item := range t.value.Content {
+			fmt.Fprintf(buf, "\n[[%s]]\n", tomlJoinKey(sub))
+			if err := tomlWriteTable(buf, sub, resolveAlias(item)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func tomlInline(n *yaml.Node) (string, error) {
+	n = resolveAlias(n)
+	switch n.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := tomlInline(c)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, v)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case yaml.MappingNode:
+		items := make([]string, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := tomlInline(n.Content[i+1])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, tomlQuoteKey(n.Content[i].Value)+" = "+v)
+		}
+		return "{" + strings.Join(items, ", ") + "}", nil
+	}
+
+	switch n.ShortTag() {
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(i, 10), nil
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return "", err
+		}
+		switch {
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		case math.IsNaN(f):
+			return "nan", nil
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEn") {
+			s += ".0"
+		}
+		return s, nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(b), nil
+	case "!!null":
+		return "", errors.New("TOML não representa null dentro de listas")
+	}
+	return tomlQuoteString(n.Value), nil
+}
+
+func tomlQuoteKey(key string) string {
+	if tomlBareKeyRe.MatchString(key) {
+		return key
+	}
+	return tomlQuoteString(key)
+}
+
+func tomlJoinKey(path []string) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = tomlQuoteKey(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+func tomlQuoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+func allMappings(n *yaml.Node) bool {
+	for _, c := range n.Content {
+		if resolveAlias(c).Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
@@ -1,33 +1,48 @@
 package config
 
+import "strconv"
+
 type ServerConfig struct {
-	Name        string `json:"name" yaml:"name"`
-	Host        string `json:"host" yaml:"host"`
-	Port        int    `json:"port" yaml:"port"`
-	Replicas    int    `json:"replicas" yaml:"replicas"`
-	Healthcheck string `json:"healthcheck" yaml:"healthcheck"`
-	Protocol    string `json:"protocol" yaml:"protocol"`
+	Name        string            `json:"name" yaml:"name" jsonschema:"required,minLength=1"`
+	Host        string            `json:"host" yaml:"host" jsonschema:"required,minLength=1"`
+	Port        int               `json:"port" yaml:"port" jsonschema:"required,minimum=1,maximum=65535"`
+	Replicas    int               `json:"replicas" yaml:"replicas" jsonschema:"minimum=0"`
+	Healthcheck string            `json:"healthcheck" yaml:"healthcheck"`
+	Protocol    string            `json:"protocol" yaml:"protocol" jsonschema:"enum=http|https"`
+	Tags        []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Interval    string            `json:"interval,omitempty" yaml:"interval,omitempty"`
 }
 
 func (s ServerConfig) String() string {
-	return s.Name + " at " + s.Host + ":" + string(rune(s.Port))
+	return s.Name + " at " + s.Host + ":" + strconv.Itoa(s.Port)
+}
+
+// HealthURL é o endereço verificado pelo health check do servidor.
+func (s ServerConfig) HealthURL() string {
+	return portName(s.Protocol) + "://" + s.Host + ":" + strconv.Itoa(s.Port) + healthcheckPath(s.Healthcheck)
 }
 
 type DatabaseConfig struct {
-	Host     string `json:"host" yaml:"host"`
-	Port     int    `json:"port" yaml:"port"`
-	User     string `json:"user" yaml:"user"`
-	Password string `json:"password" yaml:"password"`
+	Host     string `json:"host" yaml:"host" jsonschema:"required,minLength=1"`
+	Port     int    `json:"port" yaml:"port" jsonschema:"required,minimum=1,maximum=65535"`
+	User     string `json:"user" yaml:"user" jsonschema:"required,minLength=1"`
+	Password string `json:"password" yaml:"password" secret:"true"`
 }
 
 type WebsiteConfig struct {
-	Name            string `json:"name" yaml:"name"`
-	Url             string `json:"url" yaml:"url"`
-	MaxResponseTime int    `json:"max_response_time" yaml:"max_response_time"`
+	Name            string            `json:"name" yaml:"name" jsonschema:"required,minLength=1"`
+	Url             string            `json:"url" yaml:"url" jsonschema:"required,format=uri"`
+	MaxResponseTime int               `json:"max_response_time" yaml:"max_response_time" jsonschema:"required,minimum=1"`
+	Tags            []string          `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
 type Config struct {
-	Servers  []ServerConfig  `json:"servers" yaml:"servers"`
-	Database DatabaseConfig  `json:"database" yaml:"database"`
-	Website  []WebsiteConfig `json:"websites" yaml:"websites"`
+	APIVersion string          `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty" jsonschema:"enum=configparser/v1|configparser/v2"`
+	Kind       string          `json:"kind,omitempty" yaml:"kind,omitempty" jsonschema:"enum=Config"`
+	Defaults   *ConfigDefaults `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Servers    []ServerConfig  `json:"servers" yaml:"servers"`
+	Database   DatabaseConfig  `json:"database" yaml:"database" jsonschema:"required"`
+	Website    []WebsiteConfig `json:"websites" yaml:"websites"`
 }
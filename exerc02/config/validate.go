@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationError descreve um problema encontrado em um campo da configuração.
+type ValidationError struct {
+	Path     string   `json:"path" yaml:"path"`
+	Rule     string   `json:"rule" yaml:"rule"`
+	Message  string   `json:"message" yaml:"message"`
+	Severity Severity `json:"severity" yaml:"severity"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", e.Severity, e.Path, e.Message, e.Rule)
+}
+
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// HasErrors indica se existe ao menos um problema com severidade error.
+func (v ValidationErrors) HasErrors() bool {
+	for _, e := range v {
+		if e.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *ValidationErrors) add(path, rule, message string, severity Severity) {
+	*v = append(*v, ValidationError{Path: path, Rule: rule, Message: message, Severity: severity})
+}
+
+func (v *ValidationErrors) required(path string, missing bool) {
+	if missing {
+		v.add(path, "required", "campo obrigatório ausente", SeverityError)
+	}
+}
+
+func (v *ValidationErrors) port(path string, port int) {
+	if port == 0 {
+		v.required(path, true)
+		return
+	}
+	if port < 1 || port > 65535 {
+		v.add(path, "port-range", fmt.Sprintf("porta %d fora do intervalo 1-65535", port), SeverityError)
+	}
+}
+
+// Validate aplica as regras de validação e retorna todos os problemas encontrados.
+func Validate(cfg Config) ValidationErrors {
+	var errs ValidationErrors
+
+	for i, server := range cfg.Servers {
+		path := fmt.Sprintf("servers[%d]", i)
+		errs.required(path+".name", server.Name == "")
+		errs.required(path+".host", server.Host == "")
+		errs.port(path+".port", server.Port)
+		if server.Replicas < 0 {
+			errs.add(path+".replicas", "min", "replicas não pode ser negativo", SeverityError)
+		}
+		if server.Protocol == "" {
+			errs.add(path+".protocol", "required", "protocolo não informado", SeverityWarning)
+		}
+		if server.Interval != "" {
+			if d, err := time.ParseDuration(server.Interval); err != nil || d <= 0 {
+				errs.add(path+".interval", "duration", fmt.Sprintf("intervalo inválido %q (use, por exemplo, 30s ou 1m)", server.Interval), SeverityError)
+			}
+		}
+	}
+
+	db := cfg.Database
+	errs.required("database.host", db.Host == "")
+	errs.port("database.port", db.Port)
+	errs.required("database.user", db.User == "")
+
+	for i, website := range cfg.Website {
+		path := fmt.Sprintf("websites[%d]", i)
+		errs.required(path+".name", website.Name == "")
+		errs.required(path+".url", website.Url == "")
+		errs.required(path+".max_response_time", website.MaxResponseTime == 0)
+		if website.Url != "" {
+			if u, err := url.Parse(website.Url); err != nil || u.Scheme == "" || u.Host == "" {
+				errs.add(path+".url", "url", fmt.Sprintf("url inválida: %q", website.Url), SeverityError)
+			}
+		}
+		if website.MaxResponseTime < 0 {
+			errs.add(path+".max_response_time", "min", "max_response_time não pode ser negativo", SeverityError)
+		}
+	}
+
+	return errs
+}
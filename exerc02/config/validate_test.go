@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestValidateValidConfig(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{{
+			Name:     "app-server",
+			Host:     "localhost",
+			Port:     8080,
+			Replicas: 3,
+			Protocol: "http",
+		}},
+		Database: DatabaseConfig{
+			Host: "localhost",
+			Port: 5432,
+			User: "admin",
+		},
+		Website: []WebsiteConfig{{
+			Name:            "Example",
+			Url:             "https://www.example.com",
+			MaxResponseTime: 2000,
+		}},
+	}
+
+	if errs := Validate(cfg); len(errs) != 0 {
+		t.Errorf("Não eram esperados erros de validação: %v", errs)
+	}
+}
+
+func TestValidateFieldPaths(t *testing.T) {
+	cfg := Config{
+		Servers: []ServerConfig{
+			{Name: "ok", Host: "localhost", Port: 8080, Protocol: "http"},
+			{Name: "sem-porta", Host: "localhost", Protocol: "http"},
+			{Name: "porta-invalida", Host: "localhost", Port: 70000, Protocol: "http"},
+		},
+		Database: DatabaseConfig{Host: "localhost", Port: 5432},
+		Website: []WebsiteConfig{
+			{Name: "Example", Url: "example.com", MaxResponseTime: 100},
+		},
+	}
+
+	errs := Validate(cfg)
+	if !errs.HasErrors() {
+		t.Fatal("Eram esperados erros de validação")
+	}
+
+	expected := map[string]string{
+		"servers[1].port": "required",
+		"servers[2].port": "port-range",
+		"database.user":   "required",
+		"websites[0].url": "url",
+	}
+	for _, e := range errs {
+		if rule, ok := expected[e.Path]; ok && rule == e.Rule {
+			delete(expected, e.Path)
+		}
+	}
+	for path, rule := range expected {
+		t.Errorf("Erro %s não reportado para %s", rule, path)
+	}
+}
+
+func TestValidateWarningsDoNotFail(t *testing.T) {
+	cfg := Config{
+		Servers:  []ServerConfig{{Name: "app", Host: "localhost", Port: 8080}},
+		Database: DatabaseConfig{Host: "localhost", Port: 5432, User: "admin"},
+	}
+
+	errs := Validate(cfg)
+	if errs.HasErrors() {
+		t.Errorf("Avisos não deveriam ser tratados como erro: %v", errs)
+	}
+	if len(errs) != 1 || errs[0].Path != "servers[0].protocol" || errs[0].Severity != SeverityWarning {
+		t.Errorf("Aviso de protocolo esperado, obtido: %v", errs)
+	}
+}
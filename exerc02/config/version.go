@@ -0,0 +1,185 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KindConfig = "Config"
+	KindDeploy = "DeployConfig"
+
+	APIVersionV1 = "configparser/v1"
+	APIVersionV2 = "configparser/v2"
+)
+
+// Version descreve uma geração do formato de arquivo, identificada pelo cabeçalho
+// apiVersion/kind.
+type Version struct {
+	APIVersion  string `json:"apiVersion" yaml:"apiVersion"`
+	Kind        string `json:"kind" yaml:"kind"`
+	Description string `json:"description" yaml:"description"`
+	// upgrade converte a árvore desta versão para a seguinte do mesmo kind.
+	upgrade func(root *yaml.Node)
+}
+
+func (v Version) String() string {
+	return v.APIVersion + " " + v.Kind
+}
+
+// Versions é o registro das versões conhecidas, da mais antiga para a mais nova
+// dentro de cada kind.
+var Versions = []Version{
+	{APIVersion: APIVersionV1, Kind: KindConfig, Description: "servers sem healthcheck/protocol e database (exerc01)", upgrade: upgradeConfigV1},
+	{APIVersion: APIVersionV2, Kind: KindConfig, Description: "servers com healthcheck/protocol, database e websites (exerc02)"},
+	{APIVersion: APIVersionV1, Kind: KindDeploy, Description: "servers descrevendo containers: image, ports, env... (exerc03)"},
+}
+
+// LatestVersion retorna a versão mais nova registrada para kind.
+func LatestVersion(kind string) (Version, bool) {
+	var latest Version
+	found := false
+	for _, v := range Versions {
+		if v.Kind == kind {
+			latest, found = v, true
+		}
+	}
+	return latest, found
+}
+
+func lookupVersion(apiVersion, kind string) (int, bool) {
+	for i, v := range Versions {
+		if v.APIVersion == apiVersion && v.Kind == kind {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// DeclaredVersion lê o cabeçalho apiVersion/kind do documento. Retorna false se o
+// arquivo não tem cabeçalho e erro se a versão não está registrada.
+func DeclaredVersion(root *yaml.Node) (Version, bool, error) {
+	n := unwrapDocument(root)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return Version{}, false, nil
+	}
+	apiVersion, kind := mappingValue(n, "apiVersion"), mappingValue(n, "kind")
+	if apiVersion == nil && kind == nil {
+		return Version{}, false, nil
+	}
+	if apiVersion == nil || kind == nil {
+		return Version{}, false, errors.New("o cabeçalho deve ter apiVersion e kind")
+	}
+	i, ok := lookupVersion(apiVersion.Value, kind.Value)
+	if !ok {
+		return Version{}, false, fmt.Errorf("versão desconhecida %s %s", apiVersion.Value, kind.Value)
+	}
+	return Versions[i], true, nil
+}
+
+// DetectVersion é como DeclaredVersion, mas deduz a versão de arquivos sem
+// cabeçalho pelo formato dos dados: servers com image são DeployConfig; websites
+// ou servers com healthcheck/protocol indicam configparser/v2.
+func DetectVersion(root *yaml.Node) (Version, error) {
+	if v, ok, err := DeclaredVersion(root); ok || err != nil {
+		return v, err
+	}
+
+	n := unwrapDocument(root)
+	kind, apiVersion := KindConfig, APIVersionV1
+	if n != nil && n.Kind == yaml.MappingNode {
+		if mappingValue(n, "websites") != nil || mappingValue(n, "defaults") != nil {
+			apiVersion = APIVersionV2
+		}
+		if servers := mappingValue(n, "servers"); servers != nil && servers.Kind == yaml.SequenceNode {
+			for _, item := range servers.Content {
+				if mappingValue(item, "image") != nil {
+					kind = KindDeploy
+				}
+				if mappingValue(item, "healthcheck") != nil || mappingValue(item, "protocol") != nil {
+					apiVersion = APIVersionV2
+				}
+			}
+		}
+	}
+	if kind == KindDeploy {
+		apiVersion = APIVersionV1
+	}
+	i, _ := lookupVersion(apiVersion, kind)
+	return Versions[i], nil
+}
+
+// Migrate atualiza o documento para a versão mais nova do seu kind, preenchendo
+// os campos introduzidos em cada versão, e grava o cabeçalho apiVersion/kind no
+// início do arquivo. Comentários e a ordem dos campos são mantidos.
+func Migrate(root *yaml.Node) (from, to Version, err error) {
+	from, err = DetectVersion(root)
+	if err != nil {
+		return from, from, err
+	}
+	n := unwrapDocument(root)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return from, from, errors.New("o documento deve ser um objeto")
+	}
+
+	i, _ := lookupVersion(from.APIVersion, from.Kind)
+	for ; Versions[i].upgrade != nil; i++ {
+		Versions[i].upgrade(n)
+	}
+	to = Versions[i]
+	setHeader(n, to)
+	return from, to, nil
+}
+
+// setHeader grava apiVersion e kind como os primeiros campos do objeto.
+func setHeader(n *yaml.Node, v Version) {
+	header := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "apiVersion"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.APIVersion},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "kind"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.Kind},
+	}
+	var rest []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key := n.Content[i].Value
+		if key == "apiVersion" || key == "kind" {
+			continue
+		}
+		rest = append(rest, n.Content[i], n.Content[i+1])
+	}
+	if len(rest) > 0 && rest[0].HeadComment != "" {
+		// O comentário do início do arquivo continua acima do cabeçalho.
+		header[0].HeadComment, rest[0].HeadComment = rest[0].HeadComment, ""
+	}
+	n.Content = append(header, rest...)
+}
+
+// upgradeConfigV1 preenche os campos que os servidores ganharam em configparser/v2:
+// protocol (https na porta 443, http nas demais) e healthcheck (/).
+func upgradeConfigV1(n *yaml.Node) {
+	servers := mappingValue(n, "servers")
+	if servers == nil || servers.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range servers.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		if mappingValue(item, "healthcheck") == nil {
+			item.Content = append(item.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "healthcheck"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "/"})
+		}
+		if mappingValue(item, "protocol") == nil {
+			protocol := "http"
+			if port := mappingValue(item, "port"); port != nil && port.Value == "443" {
+				protocol = "https"
+			}
+			item.Content = append(item.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "protocol"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: protocol})
+		}
+	}
+}
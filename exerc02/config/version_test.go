@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectVersion(t *testing.T) {
+	tests := map[string]string{
+		"servers:\n  - name: app\n    port: 80\n":       "configparser/v1 Config",
+		"servers:\n  - name: app\n    protocol: http\n": "configparser/v2 Config",
+		"websites: []\n": "configparser/v2 Config",
+		"servers:\n  - name: web\n    image: nginx\n":                    "configparser/v1 DeployConfig",
+		"apiVersion: configparser/v1\nkind: Config\nwebsites: []\n":      "configparser/v1 Config",
+		"apiVersion: configparser/v2\nkind: Config\nservers: []\n":       "configparser/v2 Config",
+		"apiVersion: configparser/v1\nkind: DeployConfig\nservers: []\n": "configparser/v1 DeployConfig",
+	}
+	for doc, expected := range tests {
+		v, err := DetectVersion(parseTestNode(t, doc))
+		if err != nil {
+			t.Errorf("%q: erro inesperado: %v", doc, err)
+			continue
+		}
+		if v.String() != expected {
+			t.Errorf("%q: esperado %s, obtido %s", doc, expected, v)
+		}
+	}
+
+	for _, doc := range []string{"apiVersion: configparser/v9\nkind: Config\n", "kind: Config\n"} {
+		if _, err := DetectVersion(parseTestNode(t, doc)); err == nil {
+			t.Errorf("%q: era esperado erro", doc)
+		}
+	}
+}
+
+func TestMigrateV1(t *testing.T) {
+	root := parseTestNode(t, `servers:
+  - name: app # principal
+    host: localhost
+    port: 443
+  - name: api
+    host: localhost
+    port: 8080
+database:
+  host: localhost
+`)
+
+	from, to, err := Migrate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from.APIVersion != APIVersionV1 || to.APIVersion != APIVersionV2 {
+		t.Errorf("Migração inesperada: %s → %s", from, to)
+	}
+
+	out, err := Encode(root, FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"apiVersion: configparser/v2\nkind: Config\nservers:",
+		"- name: app # principal",
+		"port: 443\n    healthcheck: /\n    protocol: https",
+		"port: 8080\n    healthcheck: /\n    protocol: http\n",
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("Saída não contém %q:\n%s", expected, out)
+		}
+	}
+
+	if _, to, _ := Migrate(root); to.APIVersion != APIVersionV2 {
+		t.Errorf("Migrar novamente deveria manter a versão: %s", to)
+	}
+}
+
+func TestLoadMigratesOldVersion(t *testing.T) {
+	path := writeTestFile(t, "v1.yaml", "apiVersion: configparser/v1\nkind: Config\nservers:\n  - name: app\n    host: localhost\n    port: 80\ndatabase:\n  host: localhost\n  port: 5432\n  user: admin\n")
+
+	var warnings []ValidationError
+	cfg, err := Load(path, WithWarnings(func(w ValidationError) { warnings = append(warnings, w) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIVersion != APIVersionV2 || cfg.Servers[0].Protocol != "http" {
+		t.Errorf("Configuração não foi migrada: %+v", cfg)
+	}
+	if len(warnings) != 1 || warnings[0].Rule != "api-version" {
+		t.Errorf("Esperado aviso de versão: %v", warnings)
+	}
+
+	deploy := writeTestFile(t, "deploy.yaml", "apiVersion: configparser/v1\nkind: DeployConfig\nservers: []\n")
+	if _, err := Load(deploy); err == nil {
+		t.Error("Era esperado erro para kind DeployConfig")
+	}
+}
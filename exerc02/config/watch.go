@@ -0,0 +1,115 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventLoaded  EventType = "loaded"
+	EventChanged EventType = "changed"
+	EventInvalid EventType = "invalid"
+)
+
+// Event descreve uma recarga feita por Watch. Em EventInvalid, Errors traz os
+// problemas encontrados e a última configuração válida continua valendo.
+type Event struct {
+	Time    time.Time        `json:"time" yaml:"time"`
+	Type    EventType        `json:"type" yaml:"type"`
+	Files   []string         `json:"files" yaml:"files"`
+	Changes []Change         `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Errors  ValidationErrors `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Error   string           `json:"error,omitempty" yaml:"error,omitempty"`
+	Config  *Config          `json:"-" yaml:"-"`
+}
+
+// Watch carrega o arquivo (e os overlays) com Load e verifica o conteúdo deles a cada interval,
+// recarregando e revalidando quando algo muda. O primeiro evento é EventLoaded
+// (ou EventInvalid); depois só são emitidos eventos quando a configuração muda de
+// fato. O canal é fechado quando ctx é cancelado.
+func Watch(ctx context.Context, path string, interval time.Duration, opts ...LoadOption) <-chan Event {
+	events := make(chan Event)
+	paths := append([]string{path}, newLoadOptions(opts).overlays...)
+	w := &watcher{paths: paths, opts: opts}
+
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for first := true; ; first = false {
+			// Um erro de leitura também entra no resumo: o mesmo erro não gera
+			// recargas repetidas, mas a volta do arquivo gera.
+			sum := fileSum(paths)
+			if first || !bytes.Equal(sum, w.sum) {
+				w.sum = sum
+				if ev, ok := w.reload(); ok {
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+type watcher struct {
+	paths   []string
+	opts    []LoadOption
+	sum     []byte
+	current *Config
+	invalid bool
+}
+
+func (w *watcher) reload() (Event, bool) {
+	ev := Event{Time: time.Now(), Files: w.paths}
+	cfg, err := Load(w.paths[0], w.opts...)
+	switch {
+	case err != nil:
+		w.invalid = true
+		ev.Type, ev.Config = EventInvalid, w.current
+		if !errors.As(err, &ev.Errors) {
+			ev.Error = err.Error()
+		}
+		return ev, true
+	case w.current == nil:
+		ev.Type = EventLoaded
+	default:
+		ev.Type, ev.Changes = EventChanged, Diff(*w.current, *cfg)
+		if len(ev.Changes) == 0 && !w.invalid {
+			return ev, false
+		}
+	}
+	w.current, w.invalid = cfg, false
+	ev.Config = cfg
+	return ev, true
+}
+
+// fileSum calcula um resumo do conteúdo de todos os arquivos.
+func fileSum(paths []string) []byte {
+	h := sha256.New()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			data = []byte(err.Error())
+		}
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	return h.Sum(nil)
+}
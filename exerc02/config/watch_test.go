@@ -0,0 +1,51 @@
+package config
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWatchEmitsChanges(t *testing.T) {
+	const base = "servers:\n  - name: app\n    host: localhost\n    port: 80\n    protocol: http\ndatabase:\n  host: localhost\n  port: 5432\n  user: admin\n"
+	path := writeTestFile(t, "config.yaml", base)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := Watch(ctx, path, 10*time.Millisecond)
+
+	next := func() Event {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-ctx.Done():
+			t.Fatal("Evento não recebido")
+		}
+		return Event{}
+	}
+
+	if ev := next(); ev.Type != EventLoaded || ev.Config.Servers[0].Port != 80 {
+		t.Fatalf("Primeiro evento inesperado: %+v", ev)
+	}
+
+	if err := os.WriteFile(path, []byte("servers: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev.Type != EventInvalid || ev.Config == nil {
+		t.Fatalf("Esperado evento invalid mantendo a configuração anterior: %+v", ev)
+	}
+
+	updated := "servers:\n  - name: app\n    host: localhost\n    port: 8080\n    protocol: http\ndatabase:\n  host: localhost\n  port: 5432\n  user: admin\n"
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ev := next()
+	if ev.Type != EventChanged || len(ev.Changes) != 1 || ev.Changes[0].Name != "app" {
+		t.Fatalf("Esperada alteração do servidor app: %+v", ev)
+	}
+	if ev.Config.Servers[0].Port != 8080 {
+		t.Errorf("Configuração do evento não foi atualizada: %+v", ev.Config.Servers)
+	}
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// wizardField é uma pergunta do assistente; o mesmo campo é usado para ler as
+// especificações nome=valor passadas por flags.
+type wizardField struct {
+	key    string
+	prompt string
+	// def calcula o valor sugerido a partir das respostas anteriores.
+	def   func(answers map[string]string) string
+	check func(value string) error
+}
+
+func fixed(value string) func(map[string]string) string {
+	return func(map[string]string) string { return value }
+}
+
+var serverFields = []wizardField{
+	{"name", "Nome", fixed(""), checkRequired},
+	{"host", "Host", fixed(""), checkHost},
+	{"port", "Porta", fixed("443"), checkPort},
+	{"protocol", "Protocolo (http/https)", func(a map[string]string) string {
+		if a["port"] == "443" {
+			return "https"
+		}
+		return "http"
+	}, checkProtocol},
+	{"healthcheck", "Healthcheck", fixed("/"), checkHealthcheck},
+	{"replicas", "Réplicas", fixed("1"), checkNonNegative},
+}
+
+var databaseFields = []wizardField{
+	{"host", "Host", fixed("localhost"), checkHost},
+	{"port", "Porta", fixed("5432"), checkPort},
+	{"user", "Usuário", fixed(""), checkRequired},
+	{"password", "Senha (use ${VAR} para ler do ambiente)", fixed("${DB_PASSWORD}"), nil},
+}
+
+var websiteFields = []wizardField{
+	{"name", "Nome", fixed(""), checkRequired},
+	{"url", "URL", fixed(""), checkURL},
+	{"max_response_time", "Tempo máximo de resposta (ms)", fixed("2000"), checkPositive},
+}
+
+func checkRequired(v string) error {
+	if v == "" {
+		return errors.New("campo obrigatório")
+	}
+	return nil
+}
+
+func checkHost(v string) error {
+	if v == "" || strings.ContainsAny(v, " /:") {
+		return errors.New("informe um nome de host ou IP, sem protocolo nem porta")
+	}
+	return nil
+}
+
+func checkPort(v string) error {
+	if p, err := strconv.Atoi(v); err != nil || p < 1 || p > 65535 {
+		return errors.New("a porta deve ser um número entre 1 e 65535")
+	}
+	return nil
+}
+
+func checkProtocol(v string) error {
+	if v != "http" && v != "https" {
+		return errors.New("use http ou https")
+	}
+	return nil
+}
+
+func checkHealthcheck(v string) error {
+	if v != "" && !strings.HasPrefix(v, "/") {
+		return errors.New("o caminho deve começar com /")
+	}
+	return nil
+}
+
+func checkNonNegative(v string) error {
+	if n, err := strconv.Atoi(v); err != nil || n < 0 {
+		return errors.New("informe um número inteiro maior ou igual a 0")
+	}
+	return nil
+}
+
+func checkPositive(v string) error {
+	if n, err := strconv.Atoi(v); err != nil || n < 1 {
+		return errors.New("informe um número inteiro maior que 0")
+	}
+	return nil
+}
+
+func checkURL(v string) error {
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("informe uma URL completa, como https://example.com")
+	}
+	return nil
+}
+
+// Wizard monta uma configuração nova fazendo perguntas em Out e lendo as
+// respostas de In. Respostas inválidas são recusadas e a pergunta é repetida.
+type Wizard struct {
+	In  io.Reader
+	Out io.Writer
+
+	scanner *bufio.Scanner
+}
+
+// Run faz as perguntas sobre servidores, banco de dados e websites, nessa ordem.
+// Um nome vazio encerra a lista de servidores ou de websites.
+func (w *Wizard) Run() (Config, error) {
+	w.scanner = bufio.NewScanner(w.In)
+	cfg := Config{APIVersion: APIVersionV2, Kind: KindConfig}
+
+	fmt.Fprintln(w.Out, "Servidores (deixe o nome vazio para terminar)")
+	for {
+		answers, err := w.askEntry(serverFields, func(name string) error {
+			if slices.ContainsFunc(cfg.Servers, func(s ServerConfig) bool { return s.Name == name }) {
+				return fmt.Errorf("já existe um servidor %q", name)
+			}
+			return nil
+		})
+		if err != nil {
+			return cfg, err
+		}
+		if answers == nil {
+			break
+		}
+		server, err := inventoryServer(answers, nil)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Servers = append(cfg.Servers, server)
+	}
+
+	fmt.Fprintln(w.Out, "Banco de dados")
+	answers := map[string]string{}
+	for _, f := range databaseFields {
+		v, err := w.ask(f, answers)
+		if err != nil {
+			return cfg, err
+		}
+		answers[f.key] = v
+	}
+	db, err := databaseFromAnswers(answers)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Database = db
+
+	fmt.Fprintln(w.Out, "Websites (deixe o nome vazio para terminar)")
+	for {
+		answers, err := w.askEntry(websiteFields, func(name string) error {
+			if slices.ContainsFunc(cfg.Website, func(s WebsiteConfig) bool { return s.Name == name }) {
+				return fmt.Errorf("já existe um website %q", name)
+			}
+			return nil
+		})
+		if err != nil {
+			return cfg, err
+		}
+		if answers == nil {
+			break
+		}
+		website, err := websiteFromAnswers(answers)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Website = append(cfg.Website, website)
+	}
+	return cfg, nil
+}
+
+// askEntry pergunta os campos de uma entrada; retorna nil quando o nome fica vazio.
+func (w *Wizard) askEntry(fields []wizardField, unique func(string) error) (map[string]string, error) {
+	answers := map[string]string{}
+	for i, f := range fields {
+		if i == 0 {
+			// O nome vazio encerra a lista, então não é obrigatório aqui.
+			f.check = unique
+		}
+		v, err := w.ask(f, answers)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 && v == "" {
+			return nil, nil
+		}
+		answers[f.key] = v
+	}
+	return answers, nil
+}
+
+func (w *Wizard) ask(f wizardField, answers map[string]string) (string, error) {
+	def := f.def(answers)
+	for {
+		if def != "" {
+			fmt.Fprintf(w.Out, "  %s [%s]: ", f.prompt, def)
+		} else {
+			fmt.Fprintf(w.Out, "  %s: ", f.prompt)
+		}
+		if !w.scanner.Scan() {
+			if err := w.scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", errors.New("a entrada terminou antes do fim das perguntas")
+		}
+		v := strings.TrimSpace(w.scanner.Text())
+		if v == "" {
+			v = def
+		}
+		if f.check == nil {
+			return v, nil
+		}
+		if err := f.check(v); err != nil {
+			fmt.Fprintf(w.Out, "  valor inválido: %v\n", err)
+			continue
+		}
+		return v, nil
+	}
+}
+
+func databaseFromAnswers(a map[string]string) (DatabaseConfig, error) {
+	port, err := strconv.Atoi(a["port"])
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("porta inválida %q", a["port"])
+	}
+	return DatabaseConfig{Host: a["host"], Port: port, User: a["user"], Password: a["password"]}, nil
+}
+
+func websiteFromAnswers(a map[string]string) (WebsiteConfig, error) {
+	max, err := strconv.Atoi(a["max_response_time"])
+	if err != nil {
+		return WebsiteConfig{}, fmt.Errorf("max_response_time inválido %q", a["max_response_time"])
+	}
+	return WebsiteConfig{Name: a["name"], Url: a["url"], MaxResponseTime: max}, nil
+}
+
+// parseSpec lê uma especificação campo=valor,campo=valor, completando os campos
+// ausentes com os mesmos valores sugeridos pelo assistente.
+func parseSpec(spec string, fields []wizardField) (map[string]string, error) {
+	given := map[string]string{}
+	for _, part := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%q: use campo=valor", part)
+		}
+		if !slices.ContainsFunc(fields, func(f wizardField) bool { return f.key == key }) {
+			return nil, fmt.Errorf("campo desconhecido %q", key)
+		}
+		given[key] = strings.TrimSpace(value)
+	}
+
+	answers := map[string]string{}
+	for _, f := range fields {
+		v, ok := given[f.key]
+		if !ok {
+			v = f.def(answers)
+		}
+		if f.check != nil {
+			if err := f.check(v); err != nil {
+				return nil, fmt.Errorf("%s: %v", f.key, err)
+			}
+		}
+		answers[f.key] = v
+	}
+	return answers, nil
+}
+
+// ParseServerSpec lê um servidor no formato name=web,host=web.local,port=8080;
+// os campos omitidos recebem os valores sugeridos pelo assistente.
+func ParseServerSpec(spec string) (ServerConfig, error) {
+	answers, err := parseSpec(spec, serverFields)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+	return inventoryServer(answers, nil)
+}
+
+// ParseDatabaseSpec lê o banco de dados no formato host=db,port=5432,user=admin.
+func ParseDatabaseSpec(spec string) (DatabaseConfig, error) {
+	answers, err := parseSpec(spec, databaseFields)
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	return databaseFromAnswers(answers)
+}
+
+// ParseWebsiteSpec lê um website no formato name=GitHub,url=https://github.com.
+func ParseWebsiteSpec(spec string) (WebsiteConfig, error) {
+	answers, err := parseSpec(spec, websiteFields)
+	if err != nil {
+		return WebsiteConfig{}, err
+	}
+	return websiteFromAnswers(answers)
+}
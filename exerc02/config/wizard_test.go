@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWizard(t *testing.T) {
+	answers := strings.Join([]string{
+		// servidor com valores sugeridos e uma porta inválida antes da correta
+		"web", "web.local", "99999", "8080", "", "health", "/health", "2",
+		// nome repetido é recusado
+		"web", "api", "api.local", "", "", "", "",
+		"",
+		// banco de dados
+		"", "", "admin", "",
+		// website com URL inválida antes da correta
+		"GitHub", "github.com", "https://github.com", "",
+		"",
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	w := &Wizard{In: strings.NewReader(answers), Out: &out}
+	cfg, err := w.Run()
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+
+	expected := Config{
+		APIVersion: APIVersionV2,
+		Kind:       KindConfig,
+		Servers: []ServerConfig{
+			{Name: "web", Host: "web.local", Port: 8080, Replicas: 2, Healthcheck: "/health", Protocol: "http"},
+			{Name: "api", Host: "api.local", Port: 443, Replicas: 1, Healthcheck: "/", Protocol: "https"},
+		},
+		Database: DatabaseConfig{Host: "localhost", Port: 5432, User: "admin", Password: "${DB_PASSWORD}"},
+		Website:  []WebsiteConfig{{Name: "GitHub", Url: "https://github.com", MaxResponseTime: 2000}},
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("Configuração inesperada:\n%+v\n%+v", cfg, expected)
+	}
+	if n := strings.Count(out.String(), "valor inválido"); n != 4 {
+		t.Errorf("Esperado 4 respostas recusadas, obtido %d:\n%s", n, out.String())
+	}
+}
+
+func TestWizardEndOfInput(t *testing.T) {
+	w := &Wizard{In: strings.NewReader("web\n"), Out: &bytes.Buffer{}}
+	if _, err := w.Run(); err == nil {
+		t.Error("Esperado erro quando a entrada termina no meio das perguntas")
+	}
+}
+
+func TestParseSpecs(t *testing.T) {
+	server, err := ParseServerSpec("name=web,host=web.local,port=8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(server, ServerConfig{Name: "web", Host: "web.local", Port: 8080, Replicas: 1, Healthcheck: "/", Protocol: "http"}) {
+		t.Errorf("Servidor inesperado: %+v", server)
+	}
+	for _, spec := range []string{"name=web", "name=web,host=x,port=0", "name=web,host=x,ip=1"} {
+		if _, err := ParseServerSpec(spec); err == nil {
+			t.Errorf("Esperado erro para %q", spec)
+		}
+	}
+	if _, err := ParseWebsiteSpec("name=GitHub,url=github.com"); err == nil {
+		t.Error("Esperado erro para URL sem esquema")
+	}
+	db, err := ParseDatabaseSpec("user=admin,password=secret")
+	if err != nil || db != (DatabaseConfig{Host: "localhost", Port: 5432, User: "admin", Password: "secret"}) {
+		t.Errorf("Banco de dados inesperado: %+v (%v)", db, err)
+	}
+}
@@ -2,7 +2,8 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"io"
 	"main/config"
@@ -10,6 +11,8 @@ import (
 	"strings"
 	"sync"
 
+	parser "configparser-exerc02/config"
+
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
@@ -17,55 +20,75 @@ import (
 	"github.com/docker/go-connections/nat"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
 )
 
 var filePath string
+var requireSignature bool
+var publicKeyFile string
+
+const publicKeyEnv = "CONFIGPARSER_PUBLIC_KEY"
 
 var deployCmd = &cobra.Command{
 	Use:   "deploy",
 	Short: "Faz o parse de um arquivo de configuração YAML ou JSON",
 	Run: func(cmd *cobra.Command, args []string) {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			fmt.Println("Erro ao ler o arquivo:", err)
-			return
-		}
-
 		var wg sync.WaitGroup
 
-		var cfg config.Config
-		if yaml.Unmarshal(data, &cfg) == nil || json.Unmarshal(data, &cfg) == nil {
-			validateConfig(cfg)
-			fmt.Printf("Configuração carregada com sucesso:\n%+v\n", cfg)
-
-			deploys := make(chan config.DeployConfig, len(cfg.Deploy))
-			for w := 1; w <= 10; w++ {
-				wg.Add(1)
-				go AsyncBuildImage(&wg, deploys, w)
-			}
-
-			for _, deploy := range cfg.Deploy {
-				deploys <- deploy
-			}
+		var opts []config.LoadOption
+		if requireSignature {
+			opts = append(opts, config.WithPublicKey(readPublicKey()))
+		}
+		cfg, err := config.Load(filePath, opts...)
+		if err != nil {
+			exitLoadError(err)
+		}
+		fmt.Printf("Configuração carregada com sucesso:\n%+v\n", *cfg)
 
-			close(deploys)
-			wg.Wait()
+		deploys := make(chan config.DeployConfig, len(cfg.Deploy))
+		for w := 1; w <= 10; w++ {
+			wg.Add(1)
+			go AsyncBuildImage(&wg, deploys, w)
+		}
 
-		} else {
-			fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
-			os.Exit(1)
+		for _, deploy := range cfg.Deploy {
+			deploys <- deploy
 		}
 
+		close(deploys)
+		wg.Wait()
 	},
 }
 
-func validateConfig(cfg config.Config) {
-	for i, deploy := range cfg.Deploy {
-		if deploy.Name == "" || deploy.Image == "" {
-			fmt.Printf("Deploy #%d com campos obrigatórios ausentes\n", i)
+// exitInvalidConfig é o código de saída usado quando a validação encontra erros,
+// permitindo que pipelines diferenciem configuração inválida de falhas de leitura.
+const exitInvalidConfig = 2
+
+func exitLoadError(err error) {
+	var errs config.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e.Error())
 		}
+		fmt.Fprintln(os.Stderr, "Configuração inválida.")
+		os.Exit(exitInvalidConfig)
+	}
+	fmt.Fprintln(os.Stderr, err)
+	fmt.Println("Erro ao fazer o parse do arquivo de configuração.")
+	os.Exit(1)
+}
+
+// readPublicKey lê a chave usada por --require-signature.
+func readPublicKey() ed25519.PublicKey {
+	if publicKeyFile == "" {
+		fmt.Printf("Informe --public-key ou $%s\n", publicKeyEnv)
+		os.Exit(1)
+	}
+	key, err := parser.LoadPublicKey(publicKeyFile)
+	if err != nil {
+		fmt.Println("Erro ao ler a chave pública:", err)
+		os.Exit(1)
 	}
+	return key
 }
 
 func AsyncBuildImage(wg *sync.WaitGroup, deploys <-chan config.DeployConfig, id int) {
@@ -180,5 +203,7 @@ func InitContainer(ctx context.Context, deploy config.DeployConfig, apiClient *c
 func init() {
 	containerCmd.AddCommand(deployCmd)
 	deployCmd.Flags().StringVarP(&filePath, "file", "f", "", "Arquivo de configuração (YAML ou JSON)")
+	deployCmd.Flags().BoolVar(&requireSignature, "require-signature", false, "Recusa arquivos sem assinatura ed25519 válida (gerada pelo comando sign do exerc02)")
+	deployCmd.Flags().StringVar(&publicKeyFile, "public-key", os.Getenv(publicKeyEnv), "Chave pública ed25519 em PEM (ou $"+publicKeyEnv+")")
 	deployCmd.MarkFlagRequired("file")
 }
@@ -0,0 +1,41 @@
+package config
+
+import (
+	parser "configparser-exerc02/config"
+)
+
+// Defaults são valores aplicados aos campos não informados de cada deploy.
+type Defaults struct {
+	Deploy DeployConfig
+}
+
+// As opções são as do Load do configparser (exerc02).
+type LoadOption = parser.LoadOption
+
+var (
+	WithStrict          = parser.WithStrict
+	WithValidation      = parser.WithValidation
+	WithoutEnv          = parser.WithoutEnv
+	WithAllowMissingEnv = parser.WithAllowMissingEnv
+	WithPublicKey       = parser.WithPublicKey
+)
+
+// WithDefaults aplica valores padrão aos campos vazios após o parse.
+func WithDefaults(d Defaults) LoadOption {
+	return parser.WithEntryDefaults(parser.EntryDefaults{Key: "servers", Value: d.Deploy, List: true})
+}
+
+// Load carrega o arquivo com o Load do configparser (exerc02), decodificando no
+// Config deste exercício e validando com Validate.
+func Load(path string, opts ...LoadOption) (*Config, error) {
+	var cfg Config
+	err := parser.LoadTarget(path, parser.Target{
+		Version:  parser.Version{APIVersion: APIVersion, Kind: Kind},
+		Out:      &cfg,
+		Validate: func() ValidationErrors { return Validate(cfg) },
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -15,6 +15,15 @@ func (s DeployConfig) String() string {
 	return s.Name + " at " + s.Image + ":"
 }
 
+// APIVersion e Kind identificam o formato lido por este exercício no cabeçalho
+// opcional dos arquivos de configuração.
+const (
+	APIVersion = "configparser/v1"
+	Kind       = "DeployConfig"
+)
+
 type Config struct {
-	Deploy []DeployConfig `json:"servers" yaml:"servers"`
+	APIVersion string         `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+	Kind       string         `json:"kind,omitempty" yaml:"kind,omitempty"`
+	Deploy     []DeployConfig `json:"servers" yaml:"servers"`
 }
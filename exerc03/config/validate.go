@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+
+	parser "configparser-exerc02/config"
+)
+
+// Os tipos de validação são os do configparser (exerc02), para que os exercícios
+// reportem problemas no mesmo formato.
+type (
+	Severity         = parser.Severity
+	ValidationError  = parser.ValidationError
+	ValidationErrors = parser.ValidationErrors
+)
+
+const (
+	SeverityError   = parser.SeverityError
+	SeverityWarning = parser.SeverityWarning
+)
+
+// Validate aplica as regras de validação e retorna todos os problemas encontrados.
+func Validate(cfg Config) ValidationErrors {
+	var errs ValidationErrors
+	for i, deploy := range cfg.Deploy {
+		path := fmt.Sprintf("servers[%d]", i)
+		if deploy.Name == "" {
+			errs = append(errs, ValidationError{Path: path + ".name", Rule: "required", Message: "campo obrigatório ausente", Severity: SeverityError})
+		}
+		if deploy.Image == "" {
+			errs = append(errs, ValidationError{Path: path + ".image", Rule: "required", Message: "campo obrigatório ausente", Severity: SeverityError})
+		}
+	}
+	return errs
+}
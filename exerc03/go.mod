@@ -3,6 +3,7 @@ module main
 go 1.24.5
 
 require (
+	configparser-exerc02 v0.0.0
 	github.com/docker/docker v28.5.2+incompatible
 	github.com/spf13/cobra v1.10.2
 	gopkg.in/yaml.v3 v3.0.1
@@ -27,6 +28,7 @@ require (
 	github.com/morikuni/aec v1.1.0 // indirect
 	github.com/opencontainers/go-digest v1.0.0 // indirect
 	github.com/opencontainers/image-spec v1.1.1 // indirect
+	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
@@ -43,3 +45,6 @@ require (
 replace github.com/docker/docker/api => github.com/docker/docker v28.5.2+incompatible
 
 replace github.com/docker/docker/client => github.com/docker/docker v28.5.2+incompatible
+
+// O pacote config do exerc02 é compartilhado entre os exercícios.
+replace configparser-exerc02 => ../exerc02